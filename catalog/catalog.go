@@ -0,0 +1,295 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+var ErrNotLoaded = errors.New("catalog not loaded")
+
+var refreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "mcu_catalog_refreshes_total",
+	Help: "Attempts to refresh the MCU catalog from upstream, by result.",
+}, []string{"result"})
+
+var (
+	snapshotAgeDesc = prometheus.NewDesc(
+		"mcu_catalog_snapshot_age_seconds",
+		"Time since the MCU catalog snapshot being served was loaded.",
+		nil, nil,
+	)
+	snapshotMoviesDesc = prometheus.NewDesc(
+		"mcu_catalog_movies",
+		"Number of movies in the MCU catalog snapshot being served.",
+		nil, nil,
+	)
+)
+
+// maxChangesets is the number of changesets kept for the change feed.
+const maxChangesets = 100
+
+var (
+	_ domain.MoviesService = (*Catalog)(nil)
+	_ domain.ChangeFeed    = (*Catalog)(nil)
+	_ prometheus.Collector = (*Catalog)(nil)
+)
+
+// Catalog is a domain.MoviesService that serves an in-memory snapshot of an
+// upstream MoviesService, refreshing it in the background. When a refresh
+// fails the last good snapshot keeps being served.
+//
+// Each refresh that changes the movie list bumps the catalog version, and the
+// differences are kept as a domain.ChangeFeed.
+type Catalog struct {
+	upstream domain.MoviesService
+	interval time.Duration
+	jitter   float64
+	loads    singleflight.Group
+
+	mu         sync.RWMutex
+	snapshot   *snapshot
+	changesets []*domain.Changeset
+	onChange   []func(context.Context, *domain.Changeset)
+}
+
+type snapshot struct {
+	version  int
+	movies   domain.Movies
+	byID     map[int]*domain.Movie
+	loadedAt time.Time
+}
+
+// New returns a Catalog refreshed from upstream every interval, give or take
+// up to jitter (a fraction of interval) so replicas don't refresh in lockstep.
+func New(upstream domain.MoviesService, interval time.Duration, jitter float64) *Catalog {
+	return &Catalog{
+		upstream: upstream,
+		interval: interval,
+		jitter:   jitter,
+	}
+}
+
+// OnChange registers fn to be called after every refresh that changes the
+// catalog. Callbacks run synchronously, in the goroutine doing the refresh.
+func (c *Catalog) OnChange(fn func(ctx context.Context, changeset *domain.Changeset)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onChange = append(c.onChange, fn)
+}
+
+// Refresh loads the full movie list from upstream and swaps it in as the
+// current snapshot.
+func (c *Catalog) Refresh(ctx context.Context) error {
+	movies, err := c.upstream.GetMovies(ctx)
+	if err != nil {
+		refreshes.WithLabelValues("error").Inc()
+		return err
+	}
+
+	byID := make(map[int]*domain.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+
+	next := &snapshot{
+		version:  1,
+		movies:   movies,
+		byID:     byID,
+		loadedAt: time.Now(),
+	}
+
+	c.mu.Lock()
+	var changeset *domain.Changeset
+	if prev := c.snapshot; prev != nil {
+		next.version = prev.version
+		if changes := domain.DiffMovies(prev.movies, movies); len(changes) > 0 {
+			next.version++
+			changeset = &domain.Changeset{
+				Version:  next.version,
+				LoadedAt: next.loadedAt,
+				Changes:  changes,
+			}
+			c.changesets = append(c.changesets, changeset)
+			if len(c.changesets) > maxChangesets {
+				c.changesets = c.changesets[len(c.changesets)-maxChangesets:]
+			}
+		}
+	}
+	c.snapshot = next
+	onChange := c.onChange
+	c.mu.Unlock()
+
+	refreshes.WithLabelValues("success").Inc()
+
+	if changeset != nil {
+		for _, fn := range onChange {
+			fn(ctx, changeset)
+		}
+	}
+	return nil
+}
+
+// Run refreshes the catalog on a jittered schedule until ctx is cancelled.
+func (c *Catalog) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.nextRefresh()):
+		}
+
+		if err := c.Refresh(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("catalog refresh error", slog.Any("error", err), slog.Duration("age", c.Age()))
+		}
+	}
+}
+
+func (c *Catalog) nextRefresh() time.Duration {
+	spread := c.jitter * float64(c.interval)
+	return c.interval + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+func (c *Catalog) current(ctx context.Context) (*snapshot, error) {
+	c.mu.RLock()
+	s := c.snapshot
+	c.mu.RUnlock()
+
+	if s != nil {
+		return s, nil
+	}
+
+	// Requests made before anything is loaded share a single load, which
+	// isn't cancelled with the request that started it.
+	_, err, _ := c.loads.Do("", func() (interface{}, error) {
+		c.mu.RLock()
+		loaded := c.snapshot != nil
+		c.mu.RUnlock()
+		if loaded {
+			return nil, nil
+		}
+		return nil, c.Refresh(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		return nil, errors.Join(ErrNotLoaded, err)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot, nil
+}
+
+// GetMovies returns the movies in the current snapshot. The returned slice may
+// be reordered by the caller, but the movies it points to are shared.
+func (c *Catalog) GetMovies(ctx context.Context) (domain.Movies, error) {
+	s, err := c.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	movies := make(domain.Movies, len(s.movies))
+	copy(movies, s.movies)
+	return movies, nil
+}
+
+func (c *Catalog) GetMovie(ctx context.Context, movieID int) (*domain.Movie, error) {
+	s, err := c.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	movie, ok := s.byID[movieID]
+	if !ok {
+		return nil, domain.ErrMovieNotFound
+	}
+	return movie, nil
+}
+
+// GetChanges returns the changesets after version since, or every retained
+// changeset if since isn't positive.
+func (c *Catalog) GetChanges(ctx context.Context, since int) (*domain.CatalogChanges, error) {
+	s, err := c.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// The oldest version changes can be reported from: the first version
+	// loaded, or the version before the oldest retained changeset.
+	oldest := 1
+	if len(c.changesets) > 0 {
+		oldest = c.changesets[0].Version - 1
+	}
+	if since > 0 && since < oldest {
+		return nil, domain.ErrChangesExpired
+	}
+
+	// Versions are only kept in memory and restart from 1 with the process, so
+	// a version after the current one was served before a restart, and the
+	// changes made since are unknown.
+	if since > s.version {
+		return nil, domain.ErrChangesExpired
+	}
+
+	changesets := []*domain.Changeset{}
+	for _, changeset := range c.changesets {
+		if changeset.Version > since {
+			changesets = append(changesets, changeset)
+		}
+	}
+
+	return &domain.CatalogChanges{
+		Version:    s.version,
+		Changesets: changesets,
+	}, nil
+}
+
+// LoadedAt returns when the snapshot being served was loaded, or the zero time
+// if nothing has been loaded yet.
+func (c *Catalog) LoadedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.snapshot == nil {
+		return time.Time{}
+	}
+	return c.snapshot.loadedAt
+}
+
+// Age returns how long ago the snapshot being served was loaded.
+func (c *Catalog) Age() time.Duration {
+	loadedAt := c.LoadedAt()
+	if loadedAt.IsZero() {
+		return 0
+	}
+	return time.Since(loadedAt)
+}
+
+func (c *Catalog) Describe(ch chan<- *prometheus.Desc) {
+	ch <- snapshotAgeDesc
+	ch <- snapshotMoviesDesc
+}
+
+func (c *Catalog) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	s := c.snapshot
+	c.mu.RUnlock()
+
+	if s == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(snapshotAgeDesc, prometheus.GaugeValue, time.Since(s.loadedAt).Seconds())
+	ch <- prometheus.MustNewConstMetric(snapshotMoviesDesc, prometheus.GaugeValue, float64(len(s.movies)))
+}
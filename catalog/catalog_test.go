@@ -0,0 +1,183 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/catalog"
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+)
+
+func TestCatalog(t *testing.T) {
+	movie1 := &domain.Movie{ID: 1, Title: "Iron Man"}
+	movie2 := &domain.Movie{ID: 2, Title: "The Incredible Hulk"}
+
+	t.Run("Loads on first use and serves movies from snapshot", func(t *testing.T) {
+		upstream := new(domainfakes.FakeMoviesService)
+		upstream.GetMoviesReturns(domain.Movies{movie1, movie2}, nil)
+
+		cat := catalog.New(upstream, time.Hour, 0)
+
+		movies, err := cat.GetMovies(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Movies{movie1, movie2}, movies)
+
+		movie, err := cat.GetMovie(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, movie2, movie)
+
+		_, err = cat.GetMovie(context.Background(), 3)
+		assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+		assert.Equal(t, 1, upstream.GetMoviesCallCount())
+		assert.Equal(t, 0, upstream.GetMovieCallCount())
+		assert.False(t, cat.LoadedAt().IsZero())
+	})
+
+	t.Run("Keeps serving last good snapshot when refresh fails", func(t *testing.T) {
+		upstream := new(domainfakes.FakeMoviesService)
+		upstream.GetMoviesReturnsOnCall(0, domain.Movies{movie1}, nil)
+		upstream.GetMoviesReturnsOnCall(1, nil, errors.New("internal server error"))
+
+		cat := catalog.New(upstream, time.Hour, 0)
+		assert.NoError(t, cat.Refresh(context.Background()))
+		loadedAt := cat.LoadedAt()
+
+		assert.Error(t, cat.Refresh(context.Background()))
+		assert.Equal(t, loadedAt, cat.LoadedAt())
+
+		movies, err := cat.GetMovies(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Movies{movie1}, movies)
+	})
+
+	t.Run("Returns ErrNotLoaded when nothing could be loaded", func(t *testing.T) {
+		upstream := new(domainfakes.FakeMoviesService)
+		upstream.GetMoviesReturns(nil, errors.New("internal server error"))
+
+		cat := catalog.New(upstream, time.Hour, 0)
+
+		movies, err := cat.GetMovies(context.Background())
+		assert.ErrorIs(t, err, catalog.ErrNotLoaded)
+		assert.Nil(t, movies)
+	})
+
+	t.Run("Shares the first load between concurrent requests", func(t *testing.T) {
+		upstream := new(domainfakes.FakeMoviesService)
+		upstream.GetMoviesStub = func(ctx context.Context) (domain.Movies, error) {
+			time.Sleep(50 * time.Millisecond)
+			return nil, errors.New("internal server error")
+		}
+
+		cat := catalog.New(upstream, time.Hour, 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := cat.GetMovies(context.Background())
+				assert.ErrorIs(t, err, catalog.ErrNotLoaded)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, upstream.GetMoviesCallCount())
+	})
+
+	t.Run("Refreshes in the background", func(t *testing.T) {
+		upstream := new(domainfakes.FakeMoviesService)
+		upstream.GetMoviesReturnsOnCall(0, domain.Movies{movie1}, nil)
+		upstream.GetMoviesReturns(domain.Movies{movie1, movie2}, nil)
+
+		cat := catalog.New(upstream, 10*time.Millisecond, 0.5)
+		assert.NoError(t, cat.Refresh(context.Background()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go cat.Run(ctx)
+
+		assert.Eventually(t, func() bool {
+			movies, err := cat.GetMovies(context.Background())
+			return err == nil && len(movies) == 2
+		}, time.Second, 5*time.Millisecond)
+	})
+}
+
+func TestCatalogChanges(t *testing.T) {
+	ironMan := &domain.Movie{ID: 1, Title: "Iron Man", BoxOffice: 585171547}
+	ironManRevised := &domain.Movie{ID: 1, Title: "Iron Man", BoxOffice: 585366247}
+	hulk := &domain.Movie{ID: 2, Title: "The Incredible Hulk"}
+	ironMan2 := &domain.Movie{ID: 3, Title: "Iron Man 2"}
+
+	upstream := new(domainfakes.FakeMoviesService)
+	upstream.GetMoviesReturnsOnCall(0, domain.Movies{ironMan, hulk}, nil)
+	upstream.GetMoviesReturnsOnCall(1, domain.Movies{ironMan, hulk}, nil)
+	upstream.GetMoviesReturnsOnCall(2, domain.Movies{ironManRevised, ironMan2}, nil)
+
+	cat := catalog.New(upstream, time.Hour, 0)
+
+	var notified []*domain.Changeset
+	cat.OnChange(func(ctx context.Context, changeset *domain.Changeset) {
+		notified = append(notified, changeset)
+	})
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, cat.Refresh(context.Background()))
+	}
+
+	changes, err := cat.GetChanges(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, changes.Version)
+	assert.Len(t, changes.Changesets, 1)
+	assert.Equal(t, notified, changes.Changesets)
+
+	changeset := changes.Changesets[0]
+	assert.Equal(t, 2, changeset.Version)
+	assert.Equal(t, []*domain.MovieChange{
+		{
+			Type:    domain.ChangeChanged,
+			MovieID: 1,
+			Title:   "Iron Man",
+			Fields:  []*domain.FieldChange{{Field: "box_office", Old: 585171547, New: 585366247}},
+		},
+		{Type: domain.ChangeRemoved, MovieID: 2, Title: "The Incredible Hulk"},
+		{Type: domain.ChangeAdded, MovieID: 3, Title: "Iron Man 2"},
+	}, changeset.Changes)
+
+	changes, err = cat.GetChanges(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, changes.Version)
+	assert.Empty(t, changes.Changesets)
+
+	changes, err = cat.GetChanges(context.Background(), 3)
+	assert.ErrorIs(t, err, domain.ErrChangesExpired, "versions after the current one were served before a restart")
+	assert.Nil(t, changes)
+}
+
+func TestCatalogChangesExpired(t *testing.T) {
+	upstream := new(domainfakes.FakeMoviesService)
+	for i := 0; i < 102; i++ {
+		upstream.GetMoviesReturnsOnCall(i, domain.Movies{{ID: 1, BoxOffice: i}}, nil)
+	}
+
+	cat := catalog.New(upstream, time.Hour, 0)
+	for i := 0; i < 102; i++ {
+		assert.NoError(t, cat.Refresh(context.Background()))
+	}
+
+	changes, err := cat.GetChanges(context.Background(), 1)
+	assert.ErrorIs(t, err, domain.ErrChangesExpired)
+	assert.Nil(t, changes)
+
+	changes, err = cat.GetChanges(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 102, changes.Version)
+	assert.Len(t, changes.Changesets, 100)
+}
@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/httpapi"
+)
+
+// SignatureHeader carries the hex-encoded HMAC-SHA256 of a webhook's body,
+// keyed with the shared secret, when one is configured.
+const SignatureHeader = "X-Signature-256"
+
+var webhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "mcu_catalog_webhook_deliveries_total",
+	Help: "Attempts to deliver MCU catalog changesets to webhook subscribers, by result.",
+}, []string{"result"})
+
+// Webhooks delivers changesets to subscribers by POSTing them as JSON.
+type Webhooks struct {
+	urls   []string
+	secret []byte
+	client *http.Client
+}
+
+func NewWebhooks(urls []string, secret string, policy httpapi.Policy) *Webhooks {
+	return &Webhooks{
+		urls:   urls,
+		secret: []byte(secret),
+		client: httpapi.NewClient("webhooks", policy),
+	}
+}
+
+// Notify delivers the changeset to every subscriber concurrently, waiting
+// for all deliveries to finish. Failed deliveries are logged and dropped.
+func (w *Webhooks) Notify(ctx context.Context, changeset *domain.Changeset) {
+	body, err := json.Marshal(changeset)
+	if err != nil {
+		slog.Error("webhook encode error", slog.Any("error", err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, url := range w.urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			if err := w.deliver(ctx, url, body); err != nil {
+				webhookDeliveries.WithLabelValues("error").Inc()
+				slog.Warn("webhook delivery error", slog.Any("error", err), slog.String("url", url), slog.Int("version", changeset.Version))
+				return
+			}
+			webhookDeliveries.WithLabelValues("success").Inc()
+		}(url)
+	}
+	wg.Wait()
+}
+
+func (w *Webhooks) deliver(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(w.secret, body))
+	}
+
+	rsp, err := httpapi.Do(w.client, req, nil)
+	if err != nil {
+		return err
+	}
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return fmt.Errorf("%w: %d", httpapi.ErrStatusCodeUnknown, rsp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the signature of a webhook body, as sent in SignatureHeader.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,79 @@
+package catalog_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/catalog"
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/httpapi"
+)
+
+func TestWebhooks(t *testing.T) {
+	changeset := &domain.Changeset{
+		Version:  2,
+		LoadedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Changes:  []*domain.MovieChange{{Type: domain.ChangeAdded, MovieID: 3, Title: "Iron Man 2"}},
+	}
+
+	tt := []struct {
+		Name              string
+		Secret            string
+		ExpectedSignature bool
+	}{
+		{
+			Name:              "Delivers signed changesets when a secret is set",
+			Secret:            "s3cret",
+			ExpectedSignature: true,
+		},
+		{
+			Name: "Delivers unsigned changesets without a secret",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			var delivered []string
+			subscriber := func(name string) *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPost, r.Method)
+					assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+					body, err := io.ReadAll(r.Body)
+					assert.NoError(t, err)
+
+					if tc.ExpectedSignature {
+						assert.Equal(t, catalog.Sign([]byte(tc.Secret), body), r.Header.Get(catalog.SignatureHeader))
+					} else {
+						assert.Empty(t, r.Header.Get(catalog.SignatureHeader))
+					}
+
+					var res domain.Changeset
+					assert.NoError(t, json.Unmarshal(body, &res))
+					assert.Equal(t, changeset, &res)
+
+					delivered = append(delivered, name)
+				}))
+			}
+
+			a := subscriber("a")
+			defer a.Close()
+			failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer failing.Close()
+
+			webhooks := catalog.NewWebhooks([]string{failing.URL, a.URL}, tc.Secret, httpapi.Policy{})
+			webhooks.Notify(context.Background(), changeset)
+
+			assert.Equal(t, []string{"a"}, delivered)
+		})
+	}
+}
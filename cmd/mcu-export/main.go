@@ -0,0 +1,66 @@
+// Command mcu-export downloads the live MCU catalog and writes it to a
+// snapshot file that the API server can serve with -mcu-backend=snapshot.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jace-ys/simple-api/httpapi"
+	"github.com/jace-ys/simple-api/httpapi/mcu"
+)
+
+var (
+	out     = flag.String("out", "mcu-snapshot.json", "Path the snapshot is written to, or - for stdout.")
+	timeout = flag.Duration("timeout", 30*time.Second, "Timeout for downloading the catalog.")
+)
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "mcu-export: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	records, err := mcu.NewClient(httpapi.DefaultPolicy()).GetMovieRecords(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching movies: %w", err)
+	}
+
+	if *out == "-" {
+		return mcu.WriteSnapshot(os.Stdout, records)
+	}
+
+	tmp := *out + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	if err := mcu.WriteSnapshot(f, records); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, *out); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "wrote %d movies to %s\n", len(records), *out)
+	return nil
+}
@@ -0,0 +1,113 @@
+package covers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+var errNotCached = errors.New("not cached")
+
+// Cache is a content-addressed store of images on disk. Blobs are stored
+// under the SHA-256 of their contents, and refs map a cache key to a blob.
+//
+//	<dir>/blobs/ab/abcdef...
+//	<dir>/refs/<sha256 of key>
+type Cache struct {
+	dir string
+}
+
+func OpenCache(dir string) (*Cache, error) {
+	for _, sub := range []string{"blobs", "refs"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Get returns the cover key refers to.
+func (c *Cache) Get(key string) (*domain.Cover, error) {
+	ref, err := os.ReadFile(c.refPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errNotCached
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hash, contentType, ok := strings.Cut(string(ref), " ")
+	if !ok {
+		return nil, fmt.Errorf("corrupt cache ref for %q", key)
+	}
+
+	data, err := os.ReadFile(c.blobPath(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errNotCached
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Cover{Data: data, ContentType: contentType, Hash: hash}, nil
+}
+
+// Put stores data and points key at it.
+func (c *Cache) Put(key string, data []byte, contentType string) (*domain.Cover, error) {
+	hash := sha256Hex(data)
+
+	path := c.blobPath(hash)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := writeFile(path, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeFile(c.refPath(key), []byte(hash+" "+contentType)); err != nil {
+		return nil, err
+	}
+	return &domain.Cover{Data: data, ContentType: contentType, Hash: hash}, nil
+}
+
+func (c *Cache) blobPath(hash string) string {
+	return filepath.Join(c.dir, "blobs", hash[:2], hash)
+}
+
+func (c *Cache) refPath(key string) string {
+	return filepath.Join(c.dir, "refs", sha256Hex([]byte(key)))
+}
+
+// writeFile writes data to path atomically, so readers never see a partial
+// file.
+func writeFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
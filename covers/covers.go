@@ -0,0 +1,257 @@
+// Package covers proxies movie cover images, caching them on disk along with
+// resized variants.
+package covers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/httpapi"
+	"github.com/jace-ys/simple-api/logging"
+)
+
+const (
+	// maxSourceBytes bounds the size of images fetched from upstream.
+	maxSourceBytes = 10 << 20
+	// maxSourcePixels bounds the dimensions of images decoded, since a small
+	// file can describe an image too large to hold in memory.
+	maxSourcePixels = 5000 * 5000
+	jpegQuality     = 85
+)
+
+// widths are the widths covers are resized to. Images narrower than this are
+// never scaled up.
+var widths = map[domain.CoverSize]int{
+	domain.CoverSizeMedium: 400,
+	domain.CoverSizeThumb:  150,
+}
+
+var ErrCoverNotFound = errors.New("cover not found")
+
+var _ domain.CoversService = (*Service)(nil)
+
+// Service is a domain.CoversService that fetches covers from their source
+// once, then serves them and their resized variants from a Cache.
+type Service struct {
+	cache   *Cache
+	client  *http.Client
+	flights singleflight.Group
+}
+
+func NewService(cache *Cache, policy httpapi.Policy) *Service {
+	return &Service{
+		cache:  cache,
+		client: httpapi.NewClient("covers", policy),
+	}
+}
+
+func (s *Service) GetCover(ctx context.Context, sourceURL string, size domain.CoverSize) (*domain.Cover, error) {
+	if sourceURL == "" {
+		return Placeholder(size), nil
+	}
+
+	cover, err := s.cover(ctx, sourceURL, size)
+	if err != nil {
+		logging.FromContext(ctx).Warn("cover fetch error, serving placeholder", slog.Any("error", err), slog.String("source_url", sourceURL))
+		return Placeholder(size), nil
+	}
+	return cover, nil
+}
+
+func (s *Service) cover(ctx context.Context, sourceURL string, size domain.CoverSize) (*domain.Cover, error) {
+	if size == domain.CoverSizeOriginal {
+		return s.original(ctx, sourceURL)
+	}
+
+	key := sourceURL + "#" + string(size)
+	return s.cached(ctx, key, func(ctx context.Context) (*domain.Cover, error) {
+		original, err := s.original(ctx, sourceURL)
+		if err != nil {
+			return nil, err
+		}
+
+		data, contentType, err := resize(original.Data, widths[size])
+		if err != nil {
+			return nil, err
+		}
+		return s.cache.Put(key, data, contentType)
+	})
+}
+
+func (s *Service) original(ctx context.Context, sourceURL string) (*domain.Cover, error) {
+	key := sourceURL + "#" + string(domain.CoverSizeOriginal)
+	return s.cached(ctx, key, func(ctx context.Context) (*domain.Cover, error) {
+		data, err := s.fetch(ctx, sourceURL)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only cache what can be decoded, so every variant can be derived
+		// from the original.
+		format, err := decodeConfig(data)
+		if err != nil {
+			return nil, err
+		}
+		return s.cache.Put(key, data, "image/"+format)
+	})
+}
+
+// cached returns the cover cached under key, or else the one made by create.
+// Concurrent misses for a key share a single call to create, which isn't
+// cancelled with the request that made it.
+func (s *Service) cached(ctx context.Context, key string, create func(ctx context.Context) (*domain.Cover, error)) (*domain.Cover, error) {
+	cover, err := s.cache.Get(key)
+	if !errors.Is(err, errNotCached) {
+		return cover, err
+	}
+
+	v, err, _ := s.flights.Do(key, func() (interface{}, error) {
+		// The cover may have been cached by a call that finished since.
+		cover, err := s.cache.Get(key)
+		if !errors.Is(err, errNotCached) {
+			return cover, err
+		}
+		return create(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*domain.Cover), nil
+}
+
+func (s *Service) fetch(ctx context.Context, sourceURL string) ([]byte, error) {
+	u, err := url.Parse(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported cover URL scheme %q", u.Scheme)
+	}
+
+	ctx = httpapi.WithEndpoint(ctx, "/covers")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	switch {
+	case rsp.StatusCode == http.StatusOK:
+		// OK
+	case rsp.StatusCode == http.StatusNotFound:
+		return nil, ErrCoverNotFound
+	case 500 <= rsp.StatusCode && rsp.StatusCode <= 599:
+		return nil, fmt.Errorf("%w: %d", httpapi.ErrDownstreamUnavailable, rsp.StatusCode)
+	default:
+		return nil, fmt.Errorf("%w: %d", httpapi.ErrStatusCodeUnknown, rsp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(rsp.Body, maxSourceBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSourceBytes {
+		return nil, fmt.Errorf("cover larger than %d bytes", maxSourceBytes)
+	}
+	return data, nil
+}
+
+// resize scales the image down to width, keeping its aspect ratio. PNGs stay
+// PNGs to keep any transparency, everything else is re-encoded as JPEG.
+func resize(data []byte, width int) ([]byte, string, error) {
+	if _, err := decodeConfig(data); err != nil {
+		return nil, "", err
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode cover: %w", err)
+	}
+
+	bounds := src.Bounds()
+	dst := src
+	if bounds.Dx() > width {
+		height := bounds.Dy() * width / bounds.Dx()
+		scaled := image.NewRGBA(image.Rect(0, 0, width, max(height, 1)))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), src, bounds, draw.Over, nil)
+		dst = scaled
+	}
+
+	return encode(dst, format == "png")
+}
+
+// decodeConfig returns the format of the image, checking that it's small enough
+// to be decoded.
+func decodeConfig(data []byte) (string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("decode cover: %w", err)
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return "", fmt.Errorf("cover larger than %d pixels: %dx%d", maxSourcePixels, cfg.Width, cfg.Height)
+	}
+	return format, nil
+}
+
+func encode(img image.Image, asPNG bool) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if asPNG {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+var placeholders = func() map[domain.CoverSize]*domain.Cover {
+	fill := color.RGBA{R: 0x2b, G: 0x2b, B: 0x2b, A: 0xff}
+
+	covers := make(map[domain.CoverSize]*domain.Cover)
+	for _, size := range []domain.CoverSize{domain.CoverSizeOriginal, domain.CoverSizeMedium, domain.CoverSizeThumb} {
+		width, ok := widths[size]
+		if !ok {
+			width = 600
+		}
+
+		// Covers are posters with a 2:3 aspect ratio.
+		img := image.NewUniform(fill)
+		rect := image.NewRGBA(image.Rect(0, 0, width, width*3/2))
+		draw.Draw(rect, rect.Bounds(), img, image.Point{}, draw.Src)
+
+		data, contentType, err := encode(rect, true)
+		if err != nil {
+			panic(err)
+		}
+		covers[size] = &domain.Cover{Data: data, ContentType: contentType, Hash: sha256Hex(data), Placeholder: true}
+	}
+	return covers
+}()
+
+// Placeholder returns the cover served when a movie's cover is unavailable.
+func Placeholder(size domain.CoverSize) *domain.Cover {
+	return placeholders[size]
+}
@@ -0,0 +1,210 @@
+package covers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/covers"
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/httpapi"
+)
+
+func TestGetCover(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 600, 900))
+	for x := 0; x < 600; x++ {
+		for y := 0; y < 900; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, nil))
+	source := buf.Bytes()
+
+	tt := []struct {
+		Name                string
+		Size                domain.CoverSize
+		ExpectedWidth       int
+		ExpectedHeight      int
+		ExpectedContentType string
+	}{
+		{
+			Name:                "Serves the original cover",
+			Size:                domain.CoverSizeOriginal,
+			ExpectedWidth:       600,
+			ExpectedHeight:      900,
+			ExpectedContentType: "image/jpeg",
+		},
+		{
+			Name:                "Serves a medium cover",
+			Size:                domain.CoverSizeMedium,
+			ExpectedWidth:       400,
+			ExpectedHeight:      600,
+			ExpectedContentType: "image/jpeg",
+		},
+		{
+			Name:                "Serves a thumbnail",
+			Size:                domain.CoverSizeThumb,
+			ExpectedWidth:       150,
+			ExpectedHeight:      225,
+			ExpectedContentType: "image/jpeg",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			var fetches int
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fetches++
+				w.Header().Set("Content-Type", "image/jpeg")
+				w.Write(source)
+			}))
+			defer upstream.Close()
+
+			cache, err := covers.OpenCache(t.TempDir())
+			assert.NoError(t, err)
+			service := covers.NewService(cache, httpapi.Policy{})
+
+			for i := 0; i < 2; i++ {
+				cover, err := service.GetCover(context.Background(), upstream.URL+"/iron-man.jpg", tc.Size)
+				assert.NoError(t, err)
+				assert.False(t, cover.Placeholder)
+				assert.Equal(t, tc.ExpectedContentType, cover.ContentType)
+				assert.Len(t, cover.Hash, 64)
+
+				cfg, _, err := image.DecodeConfig(bytes.NewReader(cover.Data))
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ExpectedWidth, cfg.Width)
+				assert.Equal(t, tc.ExpectedHeight, cfg.Height)
+			}
+
+			assert.Equal(t, 1, fetches)
+		})
+	}
+}
+
+func TestGetCoverPlaceholder(t *testing.T) {
+	tt := []struct {
+		Name       string
+		SourcePath string
+		Status     int
+		Body       []byte
+	}{
+		{
+			Name:       "Serves a placeholder when upstream is missing the cover",
+			SourcePath: "/missing.jpg",
+			Status:     http.StatusNotFound,
+		},
+		{
+			Name:       "Serves a placeholder when upstream fails",
+			SourcePath: "/iron-man.jpg",
+			Status:     http.StatusInternalServerError,
+		},
+		{
+			Name:       "Serves a placeholder when the cover isn't an image",
+			SourcePath: "/iron-man.jpg",
+			Status:     http.StatusOK,
+			Body:       []byte("<html>Not an image</html>"),
+		},
+		{
+			Name:       "Serves a placeholder when the cover is too large to decode",
+			SourcePath: "/iron-man.png",
+			Status:     http.StatusOK,
+			Body:       pngHeader(100000, 100000),
+		},
+		{
+			Name: "Serves a placeholder when the movie has no cover",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.Status)
+				w.Write(tc.Body)
+			}))
+			defer upstream.Close()
+
+			cache, err := covers.OpenCache(t.TempDir())
+			assert.NoError(t, err)
+			service := covers.NewService(cache, httpapi.Policy{})
+
+			var sourceURL string
+			if tc.SourcePath != "" {
+				sourceURL = upstream.URL + tc.SourcePath
+			}
+
+			cover, err := service.GetCover(context.Background(), sourceURL, domain.CoverSizeThumb)
+			assert.NoError(t, err)
+			assert.True(t, cover.Placeholder)
+			assert.Equal(t, covers.Placeholder(domain.CoverSizeThumb), cover)
+			assert.Equal(t, "image/png", cover.ContentType)
+
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(cover.Data))
+			assert.NoError(t, err)
+			assert.Equal(t, 150, cfg.Width)
+		})
+	}
+}
+
+func TestGetCoverConcurrentMisses(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 600, 900)), nil))
+	source := buf.Bytes()
+
+	var fetches int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write(source)
+	}))
+	defer upstream.Close()
+
+	cache, err := covers.OpenCache(t.TempDir())
+	assert.NoError(t, err)
+	service := covers.NewService(cache, httpapi.Policy{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cover, err := service.GetCover(context.Background(), upstream.URL+"/iron-man.jpg", domain.CoverSizeThumb)
+			assert.NoError(t, err)
+			assert.False(t, cover.Placeholder)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+}
+
+// pngHeader returns the start of a PNG image of the given dimensions, which is
+// all that's read to find them.
+func pngHeader(width, height uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], width)
+	binary.BigEndian.PutUint32(ihdr[8:], height)
+	ihdr[12] = 8 // Bit depth, of a grayscale image.
+
+	var buf bytes.Buffer
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&buf, binary.BigEndian, uint32(len(ihdr)-4))
+	buf.Write(ihdr)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(ihdr))
+	return buf.Bytes()
+}
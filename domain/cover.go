@@ -0,0 +1,36 @@
+package domain
+
+import "context"
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . CoversService
+type CoversService interface {
+	// GetCover returns the image at sourceURL in the given size. A placeholder
+	// is returned when the image can't be fetched from its source.
+	GetCover(ctx context.Context, sourceURL string, size CoverSize) (*Cover, error)
+}
+
+type CoverSize string
+
+const (
+	CoverSizeOriginal CoverSize = "original"
+	CoverSizeMedium   CoverSize = "medium"
+	CoverSizeThumb    CoverSize = "thumb"
+)
+
+// ValidCoverSize reports whether covers can be served in size.
+func ValidCoverSize(size CoverSize) bool {
+	switch size {
+	case CoverSizeOriginal, CoverSizeMedium, CoverSizeThumb:
+		return true
+	default:
+		return false
+	}
+}
+
+type Cover struct {
+	Data        []byte
+	ContentType string
+	// Hash is the SHA-256 of Data, hex encoded.
+	Hash        string
+	Placeholder bool
+}
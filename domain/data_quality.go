@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . DataQualityService
+type DataQualityService interface {
+	// GetDataQuality returns the issues found in the most recently decoded
+	// upstream data.
+	GetDataQuality(ctx context.Context) (*DataQualityReport, error)
+}
+
+type DataQualityReport struct {
+	CheckedAt      time.Time           `json:"checked_at"`
+	TotalRecords   int                 `json:"total_records"`
+	SkippedRecords int                 `json:"skipped_records"`
+	Issues         []*DataQualityIssue `json:"issues"`
+}
+
+type DataQualityProblem string
+
+const (
+	// DataQualityMissing fields are empty and have been defaulted.
+	DataQualityMissing DataQualityProblem = "missing"
+	// DataQualityUnparsable fields couldn't be parsed and have been defaulted.
+	DataQualityUnparsable DataQualityProblem = "unparsable"
+	// DataQualityUnknown fields hold a value that isn't recognised.
+	DataQualityUnknown DataQualityProblem = "unknown"
+	// DataQualityInvalid records couldn't be used at all and were skipped.
+	DataQualityInvalid DataQualityProblem = "invalid"
+)
+
+type DataQualityIssue struct {
+	// Record is the index of the record in the upstream response.
+	Record  int                `json:"record"`
+	MovieID int                `json:"movie_id,omitempty"`
+	Field   string             `json:"field,omitempty"`
+	Problem DataQualityProblem `json:"problem"`
+	Value   string             `json:"value,omitempty"`
+	Skipped bool               `json:"skipped"`
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// unannounced are the values upstream uses for release dates that haven't
+// been set yet.
+var unannounced = map[string]bool{
+	"":            true,
+	"null":        true,
+	"tba":         true,
+	"tbd":         true,
+	"tbc":         true,
+	"unannounced": true,
+	"coming soon": true,
+}
+
+// Date is a calendar date in UTC. The zero Date is an unknown date, such as a
+// release date that hasn't been announced, and is encoded as JSON null.
+type Date struct {
+	t time.Time
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the date t falls on in UTC.
+func DateOf(t time.Time) Date {
+	t = t.UTC()
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+// Today returns the current date in UTC.
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+// ParseDate parses a YYYY-MM-DD date. Placeholders such as "TBA" parse as the
+// zero Date.
+func ParseDate(s string) (Date, error) {
+	s = strings.TrimSpace(s)
+	if unannounced[strings.ToLower(s)] {
+		return Date{}, nil
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q, must be of format YYYY-MM-DD", s)
+	}
+	return Date{t: t}, nil
+}
+
+// MustParseDate is like ParseDate but panics if s can't be parsed.
+func MustParseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// IsZero reports whether the date is unknown.
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// Year returns the date's year, or zero if it's unknown.
+func (d Date) Year() int {
+	if d.IsZero() {
+		return 0
+	}
+	return d.t.Year()
+}
+
+// Time returns midnight UTC on the date.
+func (d Date) Time() time.Time {
+	return d.t
+}
+
+// Before reports whether d is before other. Unknown dates are treated as
+// being after every known date.
+func (d Date) Before(other Date) bool {
+	switch {
+	case d.IsZero():
+		return false
+	case other.IsZero():
+		return true
+	default:
+		return d.t.Before(other.t)
+	}
+}
+
+// After reports whether d is after other, treating unknown dates as being
+// after every known date.
+func (d Date) After(other Date) bool {
+	return other.Before(d)
+}
+
+// DaysSince returns the number of days from other to d.
+func (d Date) DaysSince(other Date) int {
+	return int(d.t.Sub(other.t).Hours() / 24)
+}
+
+// String formats the date as YYYY-MM-DD, or as an empty string if it's
+// unknown.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.t.Format(dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
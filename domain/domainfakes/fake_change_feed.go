@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type FakeChangeFeed struct {
+	GetChangesStub        func(context.Context, int) (*domain.CatalogChanges, error)
+	getChangesMutex       sync.RWMutex
+	getChangesArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	getChangesReturns struct {
+		result1 *domain.CatalogChanges
+		result2 error
+	}
+	getChangesReturnsOnCall map[int]struct {
+		result1 *domain.CatalogChanges
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeChangeFeed) GetChanges(arg1 context.Context, arg2 int) (*domain.CatalogChanges, error) {
+	fake.getChangesMutex.Lock()
+	ret, specificReturn := fake.getChangesReturnsOnCall[len(fake.getChangesArgsForCall)]
+	fake.getChangesArgsForCall = append(fake.getChangesArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetChangesStub
+	fakeReturns := fake.getChangesReturns
+	fake.recordInvocation("GetChanges", []interface{}{arg1, arg2})
+	fake.getChangesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeChangeFeed) GetChangesCallCount() int {
+	fake.getChangesMutex.RLock()
+	defer fake.getChangesMutex.RUnlock()
+	return len(fake.getChangesArgsForCall)
+}
+
+func (fake *FakeChangeFeed) GetChangesCalls(stub func(context.Context, int) (*domain.CatalogChanges, error)) {
+	fake.getChangesMutex.Lock()
+	defer fake.getChangesMutex.Unlock()
+	fake.GetChangesStub = stub
+}
+
+func (fake *FakeChangeFeed) GetChangesArgsForCall(i int) (context.Context, int) {
+	fake.getChangesMutex.RLock()
+	defer fake.getChangesMutex.RUnlock()
+	argsForCall := fake.getChangesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeChangeFeed) GetChangesReturns(result1 *domain.CatalogChanges, result2 error) {
+	fake.getChangesMutex.Lock()
+	defer fake.getChangesMutex.Unlock()
+	fake.GetChangesStub = nil
+	fake.getChangesReturns = struct {
+		result1 *domain.CatalogChanges
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeChangeFeed) GetChangesReturnsOnCall(i int, result1 *domain.CatalogChanges, result2 error) {
+	fake.getChangesMutex.Lock()
+	defer fake.getChangesMutex.Unlock()
+	fake.GetChangesStub = nil
+	if fake.getChangesReturnsOnCall == nil {
+		fake.getChangesReturnsOnCall = make(map[int]struct {
+			result1 *domain.CatalogChanges
+			result2 error
+		})
+	}
+	fake.getChangesReturnsOnCall[i] = struct {
+		result1 *domain.CatalogChanges
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeChangeFeed) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getChangesMutex.RLock()
+	defer fake.getChangesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeChangeFeed) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.ChangeFeed = new(FakeChangeFeed)
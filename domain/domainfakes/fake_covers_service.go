@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type FakeCoversService struct {
+	GetCoverStub        func(context.Context, string, domain.CoverSize) (*domain.Cover, error)
+	getCoverMutex       sync.RWMutex
+	getCoverArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 domain.CoverSize
+	}
+	getCoverReturns struct {
+		result1 *domain.Cover
+		result2 error
+	}
+	getCoverReturnsOnCall map[int]struct {
+		result1 *domain.Cover
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCoversService) GetCover(arg1 context.Context, arg2 string, arg3 domain.CoverSize) (*domain.Cover, error) {
+	fake.getCoverMutex.Lock()
+	ret, specificReturn := fake.getCoverReturnsOnCall[len(fake.getCoverArgsForCall)]
+	fake.getCoverArgsForCall = append(fake.getCoverArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 domain.CoverSize
+	}{arg1, arg2, arg3})
+	stub := fake.GetCoverStub
+	fakeReturns := fake.getCoverReturns
+	fake.recordInvocation("GetCover", []interface{}{arg1, arg2, arg3})
+	fake.getCoverMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCoversService) GetCoverCallCount() int {
+	fake.getCoverMutex.RLock()
+	defer fake.getCoverMutex.RUnlock()
+	return len(fake.getCoverArgsForCall)
+}
+
+func (fake *FakeCoversService) GetCoverCalls(stub func(context.Context, string, domain.CoverSize) (*domain.Cover, error)) {
+	fake.getCoverMutex.Lock()
+	defer fake.getCoverMutex.Unlock()
+	fake.GetCoverStub = stub
+}
+
+func (fake *FakeCoversService) GetCoverArgsForCall(i int) (context.Context, string, domain.CoverSize) {
+	fake.getCoverMutex.RLock()
+	defer fake.getCoverMutex.RUnlock()
+	argsForCall := fake.getCoverArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoversService) GetCoverReturns(result1 *domain.Cover, result2 error) {
+	fake.getCoverMutex.Lock()
+	defer fake.getCoverMutex.Unlock()
+	fake.GetCoverStub = nil
+	fake.getCoverReturns = struct {
+		result1 *domain.Cover
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCoversService) GetCoverReturnsOnCall(i int, result1 *domain.Cover, result2 error) {
+	fake.getCoverMutex.Lock()
+	defer fake.getCoverMutex.Unlock()
+	fake.GetCoverStub = nil
+	if fake.getCoverReturnsOnCall == nil {
+		fake.getCoverReturnsOnCall = make(map[int]struct {
+			result1 *domain.Cover
+			result2 error
+		})
+	}
+	fake.getCoverReturnsOnCall[i] = struct {
+		result1 *domain.Cover
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCoversService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getCoverMutex.RLock()
+	defer fake.getCoverMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCoversService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.CoversService = new(FakeCoversService)
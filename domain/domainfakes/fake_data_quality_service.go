@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type FakeDataQualityService struct {
+	GetDataQualityStub        func(context.Context) (*domain.DataQualityReport, error)
+	getDataQualityMutex       sync.RWMutex
+	getDataQualityArgsForCall []struct {
+		arg1 context.Context
+	}
+	getDataQualityReturns struct {
+		result1 *domain.DataQualityReport
+		result2 error
+	}
+	getDataQualityReturnsOnCall map[int]struct {
+		result1 *domain.DataQualityReport
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDataQualityService) GetDataQuality(arg1 context.Context) (*domain.DataQualityReport, error) {
+	fake.getDataQualityMutex.Lock()
+	ret, specificReturn := fake.getDataQualityReturnsOnCall[len(fake.getDataQualityArgsForCall)]
+	fake.getDataQualityArgsForCall = append(fake.getDataQualityArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetDataQualityStub
+	fakeReturns := fake.getDataQualityReturns
+	fake.recordInvocation("GetDataQuality", []interface{}{arg1})
+	fake.getDataQualityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDataQualityService) GetDataQualityCallCount() int {
+	fake.getDataQualityMutex.RLock()
+	defer fake.getDataQualityMutex.RUnlock()
+	return len(fake.getDataQualityArgsForCall)
+}
+
+func (fake *FakeDataQualityService) GetDataQualityCalls(stub func(context.Context) (*domain.DataQualityReport, error)) {
+	fake.getDataQualityMutex.Lock()
+	defer fake.getDataQualityMutex.Unlock()
+	fake.GetDataQualityStub = stub
+}
+
+func (fake *FakeDataQualityService) GetDataQualityArgsForCall(i int) context.Context {
+	fake.getDataQualityMutex.RLock()
+	defer fake.getDataQualityMutex.RUnlock()
+	argsForCall := fake.getDataQualityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDataQualityService) GetDataQualityReturns(result1 *domain.DataQualityReport, result2 error) {
+	fake.getDataQualityMutex.Lock()
+	defer fake.getDataQualityMutex.Unlock()
+	fake.GetDataQualityStub = nil
+	fake.getDataQualityReturns = struct {
+		result1 *domain.DataQualityReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDataQualityService) GetDataQualityReturnsOnCall(i int, result1 *domain.DataQualityReport, result2 error) {
+	fake.getDataQualityMutex.Lock()
+	defer fake.getDataQualityMutex.Unlock()
+	fake.GetDataQualityStub = nil
+	if fake.getDataQualityReturnsOnCall == nil {
+		fake.getDataQualityReturnsOnCall = make(map[int]struct {
+			result1 *domain.DataQualityReport
+			result2 error
+		})
+	}
+	fake.getDataQualityReturnsOnCall[i] = struct {
+		result1 *domain.DataQualityReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDataQualityService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getDataQualityMutex.RLock()
+	defer fake.getDataQualityMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDataQualityService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.DataQualityService = new(FakeDataQualityService)
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type FakeRatingsService struct {
+	GetRatingsStub        func(context.Context) (map[int]*domain.Rating, error)
+	getRatingsMutex       sync.RWMutex
+	getRatingsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getRatingsReturns struct {
+		result1 map[int]*domain.Rating
+		result2 error
+	}
+	getRatingsReturnsOnCall map[int]struct {
+		result1 map[int]*domain.Rating
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRatingsService) GetRatings(arg1 context.Context) (map[int]*domain.Rating, error) {
+	fake.getRatingsMutex.Lock()
+	ret, specificReturn := fake.getRatingsReturnsOnCall[len(fake.getRatingsArgsForCall)]
+	fake.getRatingsArgsForCall = append(fake.getRatingsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetRatingsStub
+	fakeReturns := fake.getRatingsReturns
+	fake.recordInvocation("GetRatings", []interface{}{arg1})
+	fake.getRatingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRatingsService) GetRatingsCallCount() int {
+	fake.getRatingsMutex.RLock()
+	defer fake.getRatingsMutex.RUnlock()
+	return len(fake.getRatingsArgsForCall)
+}
+
+func (fake *FakeRatingsService) GetRatingsCalls(stub func(context.Context) (map[int]*domain.Rating, error)) {
+	fake.getRatingsMutex.Lock()
+	defer fake.getRatingsMutex.Unlock()
+	fake.GetRatingsStub = stub
+}
+
+func (fake *FakeRatingsService) GetRatingsArgsForCall(i int) context.Context {
+	fake.getRatingsMutex.RLock()
+	defer fake.getRatingsMutex.RUnlock()
+	argsForCall := fake.getRatingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRatingsService) GetRatingsReturns(result1 map[int]*domain.Rating, result2 error) {
+	fake.getRatingsMutex.Lock()
+	defer fake.getRatingsMutex.Unlock()
+	fake.GetRatingsStub = nil
+	fake.getRatingsReturns = struct {
+		result1 map[int]*domain.Rating
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRatingsService) GetRatingsReturnsOnCall(i int, result1 map[int]*domain.Rating, result2 error) {
+	fake.getRatingsMutex.Lock()
+	defer fake.getRatingsMutex.Unlock()
+	fake.GetRatingsStub = nil
+	if fake.getRatingsReturnsOnCall == nil {
+		fake.getRatingsReturnsOnCall = make(map[int]struct {
+			result1 map[int]*domain.Rating
+			result2 error
+		})
+	}
+	fake.getRatingsReturnsOnCall[i] = struct {
+		result1 map[int]*domain.Rating
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRatingsService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getRatingsMutex.RLock()
+	defer fake.getRatingsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRatingsService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.RatingsService = new(FakeRatingsService)
@@ -0,0 +1,613 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type FakeReviewStore struct {
+	DeleteReviewStub        func(context.Context, int, string) error
+	deleteReviewMutex       sync.RWMutex
+	deleteReviewArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	deleteReviewReturns struct {
+		result1 error
+	}
+	deleteReviewReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FlagReviewStub        func(context.Context, int, string) (*domain.Review, error)
+	flagReviewMutex       sync.RWMutex
+	flagReviewArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	flagReviewReturns struct {
+		result1 *domain.Review
+		result2 error
+	}
+	flagReviewReturnsOnCall map[int]struct {
+		result1 *domain.Review
+		result2 error
+	}
+	GetRatingsStub        func(context.Context) (map[int]*domain.Rating, error)
+	getRatingsMutex       sync.RWMutex
+	getRatingsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getRatingsReturns struct {
+		result1 map[int]*domain.Rating
+		result2 error
+	}
+	getRatingsReturnsOnCall map[int]struct {
+		result1 map[int]*domain.Rating
+		result2 error
+	}
+	GetReviewStub        func(context.Context, int, string) (*domain.Review, error)
+	getReviewMutex       sync.RWMutex
+	getReviewArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	getReviewReturns struct {
+		result1 *domain.Review
+		result2 error
+	}
+	getReviewReturnsOnCall map[int]struct {
+		result1 *domain.Review
+		result2 error
+	}
+	GetReviewsStub        func(context.Context, int) ([]*domain.Review, error)
+	getReviewsMutex       sync.RWMutex
+	getReviewsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	getReviewsReturns struct {
+		result1 []*domain.Review
+		result2 error
+	}
+	getReviewsReturnsOnCall map[int]struct {
+		result1 []*domain.Review
+		result2 error
+	}
+	PutReviewStub        func(context.Context, *domain.Review) (*domain.Review, bool, error)
+	putReviewMutex       sync.RWMutex
+	putReviewArgsForCall []struct {
+		arg1 context.Context
+		arg2 *domain.Review
+	}
+	putReviewReturns struct {
+		result1 *domain.Review
+		result2 bool
+		result3 error
+	}
+	putReviewReturnsOnCall map[int]struct {
+		result1 *domain.Review
+		result2 bool
+		result3 error
+	}
+	SetModerationStub        func(context.Context, int, string, domain.ModerationStatus) (*domain.Review, error)
+	setModerationMutex       sync.RWMutex
+	setModerationArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+		arg4 domain.ModerationStatus
+	}
+	setModerationReturns struct {
+		result1 *domain.Review
+		result2 error
+	}
+	setModerationReturnsOnCall map[int]struct {
+		result1 *domain.Review
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeReviewStore) DeleteReview(arg1 context.Context, arg2 int, arg3 string) error {
+	fake.deleteReviewMutex.Lock()
+	ret, specificReturn := fake.deleteReviewReturnsOnCall[len(fake.deleteReviewArgsForCall)]
+	fake.deleteReviewArgsForCall = append(fake.deleteReviewArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteReviewStub
+	fakeReturns := fake.deleteReviewReturns
+	fake.recordInvocation("DeleteReview", []interface{}{arg1, arg2, arg3})
+	fake.deleteReviewMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReviewStore) DeleteReviewCallCount() int {
+	fake.deleteReviewMutex.RLock()
+	defer fake.deleteReviewMutex.RUnlock()
+	return len(fake.deleteReviewArgsForCall)
+}
+
+func (fake *FakeReviewStore) DeleteReviewCalls(stub func(context.Context, int, string) error) {
+	fake.deleteReviewMutex.Lock()
+	defer fake.deleteReviewMutex.Unlock()
+	fake.DeleteReviewStub = stub
+}
+
+func (fake *FakeReviewStore) DeleteReviewArgsForCall(i int) (context.Context, int, string) {
+	fake.deleteReviewMutex.RLock()
+	defer fake.deleteReviewMutex.RUnlock()
+	argsForCall := fake.deleteReviewArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReviewStore) DeleteReviewReturns(result1 error) {
+	fake.deleteReviewMutex.Lock()
+	defer fake.deleteReviewMutex.Unlock()
+	fake.DeleteReviewStub = nil
+	fake.deleteReviewReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReviewStore) DeleteReviewReturnsOnCall(i int, result1 error) {
+	fake.deleteReviewMutex.Lock()
+	defer fake.deleteReviewMutex.Unlock()
+	fake.DeleteReviewStub = nil
+	if fake.deleteReviewReturnsOnCall == nil {
+		fake.deleteReviewReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReviewReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReviewStore) FlagReview(arg1 context.Context, arg2 int, arg3 string) (*domain.Review, error) {
+	fake.flagReviewMutex.Lock()
+	ret, specificReturn := fake.flagReviewReturnsOnCall[len(fake.flagReviewArgsForCall)]
+	fake.flagReviewArgsForCall = append(fake.flagReviewArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.FlagReviewStub
+	fakeReturns := fake.flagReviewReturns
+	fake.recordInvocation("FlagReview", []interface{}{arg1, arg2, arg3})
+	fake.flagReviewMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReviewStore) FlagReviewCallCount() int {
+	fake.flagReviewMutex.RLock()
+	defer fake.flagReviewMutex.RUnlock()
+	return len(fake.flagReviewArgsForCall)
+}
+
+func (fake *FakeReviewStore) FlagReviewCalls(stub func(context.Context, int, string) (*domain.Review, error)) {
+	fake.flagReviewMutex.Lock()
+	defer fake.flagReviewMutex.Unlock()
+	fake.FlagReviewStub = stub
+}
+
+func (fake *FakeReviewStore) FlagReviewArgsForCall(i int) (context.Context, int, string) {
+	fake.flagReviewMutex.RLock()
+	defer fake.flagReviewMutex.RUnlock()
+	argsForCall := fake.flagReviewArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReviewStore) FlagReviewReturns(result1 *domain.Review, result2 error) {
+	fake.flagReviewMutex.Lock()
+	defer fake.flagReviewMutex.Unlock()
+	fake.FlagReviewStub = nil
+	fake.flagReviewReturns = struct {
+		result1 *domain.Review
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) FlagReviewReturnsOnCall(i int, result1 *domain.Review, result2 error) {
+	fake.flagReviewMutex.Lock()
+	defer fake.flagReviewMutex.Unlock()
+	fake.FlagReviewStub = nil
+	if fake.flagReviewReturnsOnCall == nil {
+		fake.flagReviewReturnsOnCall = make(map[int]struct {
+			result1 *domain.Review
+			result2 error
+		})
+	}
+	fake.flagReviewReturnsOnCall[i] = struct {
+		result1 *domain.Review
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) GetRatings(arg1 context.Context) (map[int]*domain.Rating, error) {
+	fake.getRatingsMutex.Lock()
+	ret, specificReturn := fake.getRatingsReturnsOnCall[len(fake.getRatingsArgsForCall)]
+	fake.getRatingsArgsForCall = append(fake.getRatingsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetRatingsStub
+	fakeReturns := fake.getRatingsReturns
+	fake.recordInvocation("GetRatings", []interface{}{arg1})
+	fake.getRatingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReviewStore) GetRatingsCallCount() int {
+	fake.getRatingsMutex.RLock()
+	defer fake.getRatingsMutex.RUnlock()
+	return len(fake.getRatingsArgsForCall)
+}
+
+func (fake *FakeReviewStore) GetRatingsCalls(stub func(context.Context) (map[int]*domain.Rating, error)) {
+	fake.getRatingsMutex.Lock()
+	defer fake.getRatingsMutex.Unlock()
+	fake.GetRatingsStub = stub
+}
+
+func (fake *FakeReviewStore) GetRatingsArgsForCall(i int) context.Context {
+	fake.getRatingsMutex.RLock()
+	defer fake.getRatingsMutex.RUnlock()
+	argsForCall := fake.getRatingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReviewStore) GetRatingsReturns(result1 map[int]*domain.Rating, result2 error) {
+	fake.getRatingsMutex.Lock()
+	defer fake.getRatingsMutex.Unlock()
+	fake.GetRatingsStub = nil
+	fake.getRatingsReturns = struct {
+		result1 map[int]*domain.Rating
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) GetRatingsReturnsOnCall(i int, result1 map[int]*domain.Rating, result2 error) {
+	fake.getRatingsMutex.Lock()
+	defer fake.getRatingsMutex.Unlock()
+	fake.GetRatingsStub = nil
+	if fake.getRatingsReturnsOnCall == nil {
+		fake.getRatingsReturnsOnCall = make(map[int]struct {
+			result1 map[int]*domain.Rating
+			result2 error
+		})
+	}
+	fake.getRatingsReturnsOnCall[i] = struct {
+		result1 map[int]*domain.Rating
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) GetReview(arg1 context.Context, arg2 int, arg3 string) (*domain.Review, error) {
+	fake.getReviewMutex.Lock()
+	ret, specificReturn := fake.getReviewReturnsOnCall[len(fake.getReviewArgsForCall)]
+	fake.getReviewArgsForCall = append(fake.getReviewArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetReviewStub
+	fakeReturns := fake.getReviewReturns
+	fake.recordInvocation("GetReview", []interface{}{arg1, arg2, arg3})
+	fake.getReviewMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReviewStore) GetReviewCallCount() int {
+	fake.getReviewMutex.RLock()
+	defer fake.getReviewMutex.RUnlock()
+	return len(fake.getReviewArgsForCall)
+}
+
+func (fake *FakeReviewStore) GetReviewCalls(stub func(context.Context, int, string) (*domain.Review, error)) {
+	fake.getReviewMutex.Lock()
+	defer fake.getReviewMutex.Unlock()
+	fake.GetReviewStub = stub
+}
+
+func (fake *FakeReviewStore) GetReviewArgsForCall(i int) (context.Context, int, string) {
+	fake.getReviewMutex.RLock()
+	defer fake.getReviewMutex.RUnlock()
+	argsForCall := fake.getReviewArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReviewStore) GetReviewReturns(result1 *domain.Review, result2 error) {
+	fake.getReviewMutex.Lock()
+	defer fake.getReviewMutex.Unlock()
+	fake.GetReviewStub = nil
+	fake.getReviewReturns = struct {
+		result1 *domain.Review
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) GetReviewReturnsOnCall(i int, result1 *domain.Review, result2 error) {
+	fake.getReviewMutex.Lock()
+	defer fake.getReviewMutex.Unlock()
+	fake.GetReviewStub = nil
+	if fake.getReviewReturnsOnCall == nil {
+		fake.getReviewReturnsOnCall = make(map[int]struct {
+			result1 *domain.Review
+			result2 error
+		})
+	}
+	fake.getReviewReturnsOnCall[i] = struct {
+		result1 *domain.Review
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) GetReviews(arg1 context.Context, arg2 int) ([]*domain.Review, error) {
+	fake.getReviewsMutex.Lock()
+	ret, specificReturn := fake.getReviewsReturnsOnCall[len(fake.getReviewsArgsForCall)]
+	fake.getReviewsArgsForCall = append(fake.getReviewsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetReviewsStub
+	fakeReturns := fake.getReviewsReturns
+	fake.recordInvocation("GetReviews", []interface{}{arg1, arg2})
+	fake.getReviewsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReviewStore) GetReviewsCallCount() int {
+	fake.getReviewsMutex.RLock()
+	defer fake.getReviewsMutex.RUnlock()
+	return len(fake.getReviewsArgsForCall)
+}
+
+func (fake *FakeReviewStore) GetReviewsCalls(stub func(context.Context, int) ([]*domain.Review, error)) {
+	fake.getReviewsMutex.Lock()
+	defer fake.getReviewsMutex.Unlock()
+	fake.GetReviewsStub = stub
+}
+
+func (fake *FakeReviewStore) GetReviewsArgsForCall(i int) (context.Context, int) {
+	fake.getReviewsMutex.RLock()
+	defer fake.getReviewsMutex.RUnlock()
+	argsForCall := fake.getReviewsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeReviewStore) GetReviewsReturns(result1 []*domain.Review, result2 error) {
+	fake.getReviewsMutex.Lock()
+	defer fake.getReviewsMutex.Unlock()
+	fake.GetReviewsStub = nil
+	fake.getReviewsReturns = struct {
+		result1 []*domain.Review
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) GetReviewsReturnsOnCall(i int, result1 []*domain.Review, result2 error) {
+	fake.getReviewsMutex.Lock()
+	defer fake.getReviewsMutex.Unlock()
+	fake.GetReviewsStub = nil
+	if fake.getReviewsReturnsOnCall == nil {
+		fake.getReviewsReturnsOnCall = make(map[int]struct {
+			result1 []*domain.Review
+			result2 error
+		})
+	}
+	fake.getReviewsReturnsOnCall[i] = struct {
+		result1 []*domain.Review
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) PutReview(arg1 context.Context, arg2 *domain.Review) (*domain.Review, bool, error) {
+	fake.putReviewMutex.Lock()
+	ret, specificReturn := fake.putReviewReturnsOnCall[len(fake.putReviewArgsForCall)]
+	fake.putReviewArgsForCall = append(fake.putReviewArgsForCall, struct {
+		arg1 context.Context
+		arg2 *domain.Review
+	}{arg1, arg2})
+	stub := fake.PutReviewStub
+	fakeReturns := fake.putReviewReturns
+	fake.recordInvocation("PutReview", []interface{}{arg1, arg2})
+	fake.putReviewMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeReviewStore) PutReviewCallCount() int {
+	fake.putReviewMutex.RLock()
+	defer fake.putReviewMutex.RUnlock()
+	return len(fake.putReviewArgsForCall)
+}
+
+func (fake *FakeReviewStore) PutReviewCalls(stub func(context.Context, *domain.Review) (*domain.Review, bool, error)) {
+	fake.putReviewMutex.Lock()
+	defer fake.putReviewMutex.Unlock()
+	fake.PutReviewStub = stub
+}
+
+func (fake *FakeReviewStore) PutReviewArgsForCall(i int) (context.Context, *domain.Review) {
+	fake.putReviewMutex.RLock()
+	defer fake.putReviewMutex.RUnlock()
+	argsForCall := fake.putReviewArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeReviewStore) PutReviewReturns(result1 *domain.Review, result2 bool, result3 error) {
+	fake.putReviewMutex.Lock()
+	defer fake.putReviewMutex.Unlock()
+	fake.PutReviewStub = nil
+	fake.putReviewReturns = struct {
+		result1 *domain.Review
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeReviewStore) PutReviewReturnsOnCall(i int, result1 *domain.Review, result2 bool, result3 error) {
+	fake.putReviewMutex.Lock()
+	defer fake.putReviewMutex.Unlock()
+	fake.PutReviewStub = nil
+	if fake.putReviewReturnsOnCall == nil {
+		fake.putReviewReturnsOnCall = make(map[int]struct {
+			result1 *domain.Review
+			result2 bool
+			result3 error
+		})
+	}
+	fake.putReviewReturnsOnCall[i] = struct {
+		result1 *domain.Review
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeReviewStore) SetModeration(arg1 context.Context, arg2 int, arg3 string, arg4 domain.ModerationStatus) (*domain.Review, error) {
+	fake.setModerationMutex.Lock()
+	ret, specificReturn := fake.setModerationReturnsOnCall[len(fake.setModerationArgsForCall)]
+	fake.setModerationArgsForCall = append(fake.setModerationArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+		arg4 domain.ModerationStatus
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetModerationStub
+	fakeReturns := fake.setModerationReturns
+	fake.recordInvocation("SetModeration", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setModerationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReviewStore) SetModerationCallCount() int {
+	fake.setModerationMutex.RLock()
+	defer fake.setModerationMutex.RUnlock()
+	return len(fake.setModerationArgsForCall)
+}
+
+func (fake *FakeReviewStore) SetModerationCalls(stub func(context.Context, int, string, domain.ModerationStatus) (*domain.Review, error)) {
+	fake.setModerationMutex.Lock()
+	defer fake.setModerationMutex.Unlock()
+	fake.SetModerationStub = stub
+}
+
+func (fake *FakeReviewStore) SetModerationArgsForCall(i int) (context.Context, int, string, domain.ModerationStatus) {
+	fake.setModerationMutex.RLock()
+	defer fake.setModerationMutex.RUnlock()
+	argsForCall := fake.setModerationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeReviewStore) SetModerationReturns(result1 *domain.Review, result2 error) {
+	fake.setModerationMutex.Lock()
+	defer fake.setModerationMutex.Unlock()
+	fake.SetModerationStub = nil
+	fake.setModerationReturns = struct {
+		result1 *domain.Review
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) SetModerationReturnsOnCall(i int, result1 *domain.Review, result2 error) {
+	fake.setModerationMutex.Lock()
+	defer fake.setModerationMutex.Unlock()
+	fake.SetModerationStub = nil
+	if fake.setModerationReturnsOnCall == nil {
+		fake.setModerationReturnsOnCall = make(map[int]struct {
+			result1 *domain.Review
+			result2 error
+		})
+	}
+	fake.setModerationReturnsOnCall[i] = struct {
+		result1 *domain.Review
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReviewStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteReviewMutex.RLock()
+	defer fake.deleteReviewMutex.RUnlock()
+	fake.flagReviewMutex.RLock()
+	defer fake.flagReviewMutex.RUnlock()
+	fake.getRatingsMutex.RLock()
+	defer fake.getRatingsMutex.RUnlock()
+	fake.getReviewMutex.RLock()
+	defer fake.getReviewMutex.RUnlock()
+	fake.getReviewsMutex.RLock()
+	defer fake.getReviewsMutex.RUnlock()
+	fake.putReviewMutex.RLock()
+	defer fake.putReviewMutex.RUnlock()
+	fake.setModerationMutex.RLock()
+	defer fake.setModerationMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeReviewStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.ReviewStore = new(FakeReviewStore)
@@ -0,0 +1,368 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type FakeWatchlistStore struct {
+	AddToWatchlistStub        func(context.Context, string, int, time.Time) (*domain.WatchlistEntry, error)
+	addToWatchlistMutex       sync.RWMutex
+	addToWatchlistArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 time.Time
+	}
+	addToWatchlistReturns struct {
+		result1 *domain.WatchlistEntry
+		result2 error
+	}
+	addToWatchlistReturnsOnCall map[int]struct {
+		result1 *domain.WatchlistEntry
+		result2 error
+	}
+	GetWatchlistStub        func(context.Context, string) (domain.Watchlist, error)
+	getWatchlistMutex       sync.RWMutex
+	getWatchlistArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getWatchlistReturns struct {
+		result1 domain.Watchlist
+		result2 error
+	}
+	getWatchlistReturnsOnCall map[int]struct {
+		result1 domain.Watchlist
+		result2 error
+	}
+	RemoveFromWatchlistStub        func(context.Context, string, int) error
+	removeFromWatchlistMutex       sync.RWMutex
+	removeFromWatchlistArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}
+	removeFromWatchlistReturns struct {
+		result1 error
+	}
+	removeFromWatchlistReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetWatchedStub        func(context.Context, string, int, *time.Time) (*domain.WatchlistEntry, error)
+	setWatchedMutex       sync.RWMutex
+	setWatchedArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 *time.Time
+	}
+	setWatchedReturns struct {
+		result1 *domain.WatchlistEntry
+		result2 error
+	}
+	setWatchedReturnsOnCall map[int]struct {
+		result1 *domain.WatchlistEntry
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWatchlistStore) AddToWatchlist(arg1 context.Context, arg2 string, arg3 int, arg4 time.Time) (*domain.WatchlistEntry, error) {
+	fake.addToWatchlistMutex.Lock()
+	ret, specificReturn := fake.addToWatchlistReturnsOnCall[len(fake.addToWatchlistArgsForCall)]
+	fake.addToWatchlistArgsForCall = append(fake.addToWatchlistArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 time.Time
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AddToWatchlistStub
+	fakeReturns := fake.addToWatchlistReturns
+	fake.recordInvocation("AddToWatchlist", []interface{}{arg1, arg2, arg3, arg4})
+	fake.addToWatchlistMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWatchlistStore) AddToWatchlistCallCount() int {
+	fake.addToWatchlistMutex.RLock()
+	defer fake.addToWatchlistMutex.RUnlock()
+	return len(fake.addToWatchlistArgsForCall)
+}
+
+func (fake *FakeWatchlistStore) AddToWatchlistCalls(stub func(context.Context, string, int, time.Time) (*domain.WatchlistEntry, error)) {
+	fake.addToWatchlistMutex.Lock()
+	defer fake.addToWatchlistMutex.Unlock()
+	fake.AddToWatchlistStub = stub
+}
+
+func (fake *FakeWatchlistStore) AddToWatchlistArgsForCall(i int) (context.Context, string, int, time.Time) {
+	fake.addToWatchlistMutex.RLock()
+	defer fake.addToWatchlistMutex.RUnlock()
+	argsForCall := fake.addToWatchlistArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWatchlistStore) AddToWatchlistReturns(result1 *domain.WatchlistEntry, result2 error) {
+	fake.addToWatchlistMutex.Lock()
+	defer fake.addToWatchlistMutex.Unlock()
+	fake.AddToWatchlistStub = nil
+	fake.addToWatchlistReturns = struct {
+		result1 *domain.WatchlistEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchlistStore) AddToWatchlistReturnsOnCall(i int, result1 *domain.WatchlistEntry, result2 error) {
+	fake.addToWatchlistMutex.Lock()
+	defer fake.addToWatchlistMutex.Unlock()
+	fake.AddToWatchlistStub = nil
+	if fake.addToWatchlistReturnsOnCall == nil {
+		fake.addToWatchlistReturnsOnCall = make(map[int]struct {
+			result1 *domain.WatchlistEntry
+			result2 error
+		})
+	}
+	fake.addToWatchlistReturnsOnCall[i] = struct {
+		result1 *domain.WatchlistEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchlistStore) GetWatchlist(arg1 context.Context, arg2 string) (domain.Watchlist, error) {
+	fake.getWatchlistMutex.Lock()
+	ret, specificReturn := fake.getWatchlistReturnsOnCall[len(fake.getWatchlistArgsForCall)]
+	fake.getWatchlistArgsForCall = append(fake.getWatchlistArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetWatchlistStub
+	fakeReturns := fake.getWatchlistReturns
+	fake.recordInvocation("GetWatchlist", []interface{}{arg1, arg2})
+	fake.getWatchlistMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWatchlistStore) GetWatchlistCallCount() int {
+	fake.getWatchlistMutex.RLock()
+	defer fake.getWatchlistMutex.RUnlock()
+	return len(fake.getWatchlistArgsForCall)
+}
+
+func (fake *FakeWatchlistStore) GetWatchlistCalls(stub func(context.Context, string) (domain.Watchlist, error)) {
+	fake.getWatchlistMutex.Lock()
+	defer fake.getWatchlistMutex.Unlock()
+	fake.GetWatchlistStub = stub
+}
+
+func (fake *FakeWatchlistStore) GetWatchlistArgsForCall(i int) (context.Context, string) {
+	fake.getWatchlistMutex.RLock()
+	defer fake.getWatchlistMutex.RUnlock()
+	argsForCall := fake.getWatchlistArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWatchlistStore) GetWatchlistReturns(result1 domain.Watchlist, result2 error) {
+	fake.getWatchlistMutex.Lock()
+	defer fake.getWatchlistMutex.Unlock()
+	fake.GetWatchlistStub = nil
+	fake.getWatchlistReturns = struct {
+		result1 domain.Watchlist
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchlistStore) GetWatchlistReturnsOnCall(i int, result1 domain.Watchlist, result2 error) {
+	fake.getWatchlistMutex.Lock()
+	defer fake.getWatchlistMutex.Unlock()
+	fake.GetWatchlistStub = nil
+	if fake.getWatchlistReturnsOnCall == nil {
+		fake.getWatchlistReturnsOnCall = make(map[int]struct {
+			result1 domain.Watchlist
+			result2 error
+		})
+	}
+	fake.getWatchlistReturnsOnCall[i] = struct {
+		result1 domain.Watchlist
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchlistStore) RemoveFromWatchlist(arg1 context.Context, arg2 string, arg3 int) error {
+	fake.removeFromWatchlistMutex.Lock()
+	ret, specificReturn := fake.removeFromWatchlistReturnsOnCall[len(fake.removeFromWatchlistArgsForCall)]
+	fake.removeFromWatchlistArgsForCall = append(fake.removeFromWatchlistArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.RemoveFromWatchlistStub
+	fakeReturns := fake.removeFromWatchlistReturns
+	fake.recordInvocation("RemoveFromWatchlist", []interface{}{arg1, arg2, arg3})
+	fake.removeFromWatchlistMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWatchlistStore) RemoveFromWatchlistCallCount() int {
+	fake.removeFromWatchlistMutex.RLock()
+	defer fake.removeFromWatchlistMutex.RUnlock()
+	return len(fake.removeFromWatchlistArgsForCall)
+}
+
+func (fake *FakeWatchlistStore) RemoveFromWatchlistCalls(stub func(context.Context, string, int) error) {
+	fake.removeFromWatchlistMutex.Lock()
+	defer fake.removeFromWatchlistMutex.Unlock()
+	fake.RemoveFromWatchlistStub = stub
+}
+
+func (fake *FakeWatchlistStore) RemoveFromWatchlistArgsForCall(i int) (context.Context, string, int) {
+	fake.removeFromWatchlistMutex.RLock()
+	defer fake.removeFromWatchlistMutex.RUnlock()
+	argsForCall := fake.removeFromWatchlistArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWatchlistStore) RemoveFromWatchlistReturns(result1 error) {
+	fake.removeFromWatchlistMutex.Lock()
+	defer fake.removeFromWatchlistMutex.Unlock()
+	fake.RemoveFromWatchlistStub = nil
+	fake.removeFromWatchlistReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWatchlistStore) RemoveFromWatchlistReturnsOnCall(i int, result1 error) {
+	fake.removeFromWatchlistMutex.Lock()
+	defer fake.removeFromWatchlistMutex.Unlock()
+	fake.RemoveFromWatchlistStub = nil
+	if fake.removeFromWatchlistReturnsOnCall == nil {
+		fake.removeFromWatchlistReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeFromWatchlistReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWatchlistStore) SetWatched(arg1 context.Context, arg2 string, arg3 int, arg4 *time.Time) (*domain.WatchlistEntry, error) {
+	fake.setWatchedMutex.Lock()
+	ret, specificReturn := fake.setWatchedReturnsOnCall[len(fake.setWatchedArgsForCall)]
+	fake.setWatchedArgsForCall = append(fake.setWatchedArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 *time.Time
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetWatchedStub
+	fakeReturns := fake.setWatchedReturns
+	fake.recordInvocation("SetWatched", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setWatchedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWatchlistStore) SetWatchedCallCount() int {
+	fake.setWatchedMutex.RLock()
+	defer fake.setWatchedMutex.RUnlock()
+	return len(fake.setWatchedArgsForCall)
+}
+
+func (fake *FakeWatchlistStore) SetWatchedCalls(stub func(context.Context, string, int, *time.Time) (*domain.WatchlistEntry, error)) {
+	fake.setWatchedMutex.Lock()
+	defer fake.setWatchedMutex.Unlock()
+	fake.SetWatchedStub = stub
+}
+
+func (fake *FakeWatchlistStore) SetWatchedArgsForCall(i int) (context.Context, string, int, *time.Time) {
+	fake.setWatchedMutex.RLock()
+	defer fake.setWatchedMutex.RUnlock()
+	argsForCall := fake.setWatchedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWatchlistStore) SetWatchedReturns(result1 *domain.WatchlistEntry, result2 error) {
+	fake.setWatchedMutex.Lock()
+	defer fake.setWatchedMutex.Unlock()
+	fake.SetWatchedStub = nil
+	fake.setWatchedReturns = struct {
+		result1 *domain.WatchlistEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchlistStore) SetWatchedReturnsOnCall(i int, result1 *domain.WatchlistEntry, result2 error) {
+	fake.setWatchedMutex.Lock()
+	defer fake.setWatchedMutex.Unlock()
+	fake.SetWatchedStub = nil
+	if fake.setWatchedReturnsOnCall == nil {
+		fake.setWatchedReturnsOnCall = make(map[int]struct {
+			result1 *domain.WatchlistEntry
+			result2 error
+		})
+	}
+	fake.setWatchedReturnsOnCall[i] = struct {
+		result1 *domain.WatchlistEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchlistStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.addToWatchlistMutex.RLock()
+	defer fake.addToWatchlistMutex.RUnlock()
+	fake.getWatchlistMutex.RLock()
+	defer fake.getWatchlistMutex.RUnlock()
+	fake.removeFromWatchlistMutex.RLock()
+	defer fake.removeFromWatchlistMutex.RUnlock()
+	fake.setWatchedMutex.RLock()
+	defer fake.setWatchedMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWatchlistStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.WatchlistStore = new(FakeWatchlistStore)
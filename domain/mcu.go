@@ -2,16 +2,16 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"math"
 	"sort"
-
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 var (
 	ErrSagaNotFound  = errors.New("saga not found")
 	ErrMovieNotFound = errors.New("movie not found")
+	ErrPhaseNotFound = errors.New("phase not found")
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . MoviesService
@@ -24,16 +24,23 @@ type Sagas []*Saga
 
 func (s Sagas) Len() int           { return len(s) }
 func (s Sagas) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func (s Sagas) Less(i, j int) bool { return s[i].StartDate < s[j].StartDate }
+func (s Sagas) Less(i, j int) bool { return s[i].StartDate.Before(s[j].StartDate) }
 
 type Saga struct {
-	Name                 string `json:"name"`
-	StartDate            string `json:"start_date"`
-	EndDate              string `json:"end_date"`
-	TotalBoxOffice       int    `json:"total_box_office"`
-	TotalDurationMinutes int    `json:"total_duration_minutes"`
-	TotalMovies          int    `json:"total_movies"`
-	Phases               Phases `json:"phases"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	Aggregates
+	Phases Phases `json:"phases"`
+}
+
+// GetPhase returns the phase of the saga with the given number.
+func (s *Saga) GetPhase(number int) (*Phase, error) {
+	for _, phase := range s.Phases {
+		if phase.Number == number {
+			return phase, nil
+		}
+	}
+	return nil, ErrPhaseNotFound
 }
 
 type Phases []*Phase
@@ -43,82 +50,138 @@ func (s Phases) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 func (s Phases) Less(i, j int) bool { return s[i].Number < s[j].Number }
 
 type Phase struct {
-	Number int    `json:"number"`
+	Number int `json:"number"`
+	Aggregates
 	Movies Movies `json:"movies"`
 }
 
+// Aggregates summarises a group of movies, such as a saga or phase.
+type Aggregates struct {
+	StartDate            Date    `json:"start_date"`
+	EndDate              Date    `json:"end_date"`
+	TotalBoxOffice       int     `json:"total_box_office"`
+	TotalDurationMinutes int     `json:"total_duration_minutes"`
+	TotalMovies          int     `json:"total_movies"`
+	AvgPostCreditScenes  float64 `json:"avg_post_credit_scenes"`
+}
+
 type Movies []*Movie
 
 type Movie struct {
 	ID               int    `json:"id"`
 	Title            string `json:"title"`
-	ReleaseDate      string `json:"release_date"`
+	ReleaseDate      Date   `json:"release_date"`
 	BoxOffice        int    `json:"box_office"`
-	DurationMinutes  int    `json:"duration_minutse"`
+	DurationMinutes  int    `json:"duration_minutse" graphql:"duration_minutes"`
 	Overview         string `json:"overview"`
+	CoverURL         string `json:"cover_url"`
+	TrailerURL       string `json:"trailer_url"`
+	DirectedBy       string `json:"directed_by"`
 	Phase            int    `json:"phase"`
 	Saga             string `json:"saga"`
 	Chronology       int    `json:"chronology"`
 	PostCreditScenes int    `json:"post_credit_scenes"`
+	ImdbID           string `json:"imdb_id"`
+	// Rating is the average user rating, or zero if the movie has no reviews.
+	Rating      float64 `json:"rating"`
+	ReviewCount int     `json:"review_count"`
+	// Upcoming is set when the movie is encoded, based on the current date.
+	Upcoming bool `json:"upcoming"`
 }
 
-func (m Movies) GroupBySaga() []*Saga {
-	sm := make(map[string]Movies)
-	for _, movie := range m {
-		sm[movie.Saga] = append(sm[movie.Saga], movie)
-	}
+func (m Movie) MarshalJSON() ([]byte, error) {
+	type movie Movie
+	encoded := movie(m)
+	encoded.Upcoming = m.IsUpcoming(Today())
+	return json.Marshal(encoded)
+}
 
-	sagas := Sagas{}
-	for name, sagaMovies := range sm {
-		var boxOffice int
-		var durationMinutes int
+// IsUpcoming reports whether the movie hasn't been released as of today,
+// including movies whose release date hasn't been announced.
+func (m *Movie) IsUpcoming(today Date) bool {
+	return m.ReleaseDate.IsZero() || m.ReleaseDate.After(today)
+}
+
+// ReleaseYear returns the year the movie was released in, or zero if its
+// release date is unknown.
+func (m *Movie) ReleaseYear() int {
+	return m.ReleaseDate.Year()
+}
+
+// Aggregate computes the aggregates of the movies. The date span covers the
+// earliest and latest known release dates.
+func (m Movies) Aggregate() Aggregates {
+	var agg Aggregates
+	var postCreditScenes int
+
+	for _, movie := range m {
+		agg.TotalBoxOffice += movie.BoxOffice
+		agg.TotalDurationMinutes += movie.DurationMinutes
+		postCreditScenes += movie.PostCreditScenes
 
-		for _, movie := range sagaMovies {
-			boxOffice += movie.BoxOffice
-			durationMinutes += movie.DurationMinutes
+		if movie.ReleaseDate.IsZero() {
+			continue
 		}
+		if agg.StartDate.IsZero() || movie.ReleaseDate.Before(agg.StartDate) {
+			agg.StartDate = movie.ReleaseDate
+		}
+		if agg.EndDate.IsZero() || movie.ReleaseDate.After(agg.EndDate) {
+			agg.EndDate = movie.ReleaseDate
+		}
+	}
 
-		sagas = append(sagas, &Saga{
-			Name:                 name,
-			StartDate:            sagaMovies[0].ReleaseDate,
-			EndDate:              sagaMovies[len(sagaMovies)-1].ReleaseDate,
-			TotalBoxOffice:       boxOffice,
-			TotalDurationMinutes: durationMinutes,
-			TotalMovies:          len(sagaMovies),
-			Phases:               sagaMovies.GroupByPhase(),
-		})
+	agg.TotalMovies = len(m)
+	if len(m) > 0 {
+		agg.AvgPostCreditScenes = math.Round(float64(postCreditScenes)/float64(len(m))*100) / 100
 	}
 
-	sort.Sort(sagas)
-	return sagas
+	return agg
 }
 
-func (m Movies) GetSaga(name string) (*Saga, error) {
+func (m Movies) groupBySaga() map[string]Movies {
 	sm := make(map[string]Movies)
 	for _, movie := range m {
 		sm[movie.Saga] = append(sm[movie.Saga], movie)
 	}
+	return sm
+}
 
-	sagaName := cases.Title(language.English).String(name)
-	sagaMovies, ok := sm[sagaName]
-	if !ok {
-		return nil, ErrSagaNotFound
+func newSaga(name string, movies Movies) *Saga {
+	return &Saga{
+		Name:       name,
+		Slug:       Slugify(name),
+		Aggregates: movies.Aggregate(),
+		Phases:     movies.GroupByPhase(),
 	}
+}
+
+func (m Movies) GroupBySaga() []*Saga {
+	sagas := Sagas{}
+	for name, sagaMovies := range m.groupBySaga() {
+		sagas = append(sagas, newSaga(name, sagaMovies))
+	}
+
+	sort.Sort(sagas)
+	return sagas
+}
+
+// GetSaga returns the saga referred to by name, which may be its name or slug
+// in any case, or a prefix of its slug that no other saga shares. If no saga
+// matches, the returned *SagaNotFoundError suggests close matches.
+func (m Movies) GetSaga(name string) (*Saga, error) {
+	sm := m.groupBySaga()
 
-	saga := &Saga{
-		Name:        sagaName,
-		StartDate:   sagaMovies[0].ReleaseDate,
-		EndDate:     sagaMovies[len(sagaMovies)-1].ReleaseDate,
-		TotalMovies: len(sagaMovies),
-		Phases:      sagaMovies.GroupByPhase(),
+	names := make([]string, 0, len(sm))
+	for n := range sm {
+		names = append(names, n)
 	}
 
-	for _, movie := range sagaMovies {
-		saga.TotalBoxOffice += movie.BoxOffice
-		saga.TotalDurationMinutes += movie.DurationMinutes
+	sagaName, err := matchSaga(names, name)
+	if err != nil {
+		return nil, err
 	}
 
-	return saga, nil
+	return newSaga(sagaName, sm[sagaName]), nil
 }
 
 func (m Movies) GroupByPhase() Phases {
@@ -130,8 +193,9 @@ func (m Movies) GroupByPhase() Phases {
 	phases := Phases{}
 	for num, phaseMovies := range pm {
 		phases = append(phases, &Phase{
-			Number: num,
-			Movies: phaseMovies,
+			Number:     num,
+			Aggregates: phaseMovies.Aggregate(),
+			Movies:     phaseMovies,
 		})
 	}
 
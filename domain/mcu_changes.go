@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrChangesExpired = errors.New("changes no longer retained")
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ChangeFeed
+type ChangeFeed interface {
+	// GetChanges returns the changesets made after the given catalog version.
+	// It returns ErrChangesExpired if some of them are no longer retained, or
+	// since is after the current version, as it is when versions restarted.
+	GetChanges(ctx context.Context, since int) (*CatalogChanges, error)
+}
+
+type CatalogChanges struct {
+	// Version is the version of the catalog currently being served.
+	Version    int          `json:"version"`
+	Changesets []*Changeset `json:"changesets"`
+}
+
+// Changeset is the difference between a catalog version and the one before.
+type Changeset struct {
+	Version  int            `json:"version"`
+	LoadedAt time.Time      `json:"loaded_at"`
+	Changes  []*MovieChange `json:"changes"`
+}
+
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeRemoved ChangeType = "removed"
+	ChangeChanged ChangeType = "changed"
+)
+
+type MovieChange struct {
+	Type    ChangeType `json:"type"`
+	MovieID int        `json:"movie_id"`
+	Title   string     `json:"title"`
+	// Fields lists the fields that changed, for changed movies only.
+	Fields []*FieldChange `json:"fields,omitempty"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// derivedMovieFields are fields of Movie that aren't part of the upstream
+// catalog, so are never diffed.
+var derivedMovieFields = map[string]bool{
+	"rating":       true,
+	"review_count": true,
+	"upcoming":     true,
+}
+
+// DiffMovies returns the changes from old to new, ordered by movie ID.
+func DiffMovies(old, new Movies) []*MovieChange {
+	oldByID := make(map[int]*Movie, len(old))
+	for _, movie := range old {
+		oldByID[movie.ID] = movie
+	}
+
+	changes := []*MovieChange{}
+	seen := make(map[int]bool, len(new))
+	for _, movie := range new {
+		seen[movie.ID] = true
+
+		before, ok := oldByID[movie.ID]
+		if !ok {
+			changes = append(changes, &MovieChange{Type: ChangeAdded, MovieID: movie.ID, Title: movie.Title})
+			continue
+		}
+
+		if fields := diffMovie(before, movie); len(fields) > 0 {
+			changes = append(changes, &MovieChange{Type: ChangeChanged, MovieID: movie.ID, Title: movie.Title, Fields: fields})
+		}
+	}
+
+	for _, movie := range old {
+		if !seen[movie.ID] {
+			changes = append(changes, &MovieChange{Type: ChangeRemoved, MovieID: movie.ID, Title: movie.Title})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].MovieID < changes[j].MovieID })
+	return changes
+}
+
+// diffMovie compares every upstream field of two versions of a movie, naming
+// fields by their JSON keys.
+func diffMovie(old, new *Movie) []*FieldChange {
+	var fields []*FieldChange
+
+	vo, vn := reflect.ValueOf(*old), reflect.ValueOf(*new)
+	t := vo.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || derivedMovieFields[name] {
+			continue
+		}
+
+		before, after := vo.Field(i).Interface(), vn.Field(i).Interface()
+		if !reflect.DeepEqual(before, after) {
+			fields = append(fields, &FieldChange{Field: name, Old: before, New: after})
+		}
+	}
+
+	return fields
+}
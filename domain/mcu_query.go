@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+type MovieFilter struct {
+	Saga                string
+	Phases              []int
+	PhaseFrom           int
+	PhaseTo             int
+	Director            string
+	ReleaseYearFrom     int
+	ReleaseYearTo       int
+	MinBoxOffice        int
+	HasPostCreditScenes *bool
+}
+
+func (f MovieFilter) matches(movie *Movie) bool {
+	if f.Saga != "" && Slugify(movie.Saga) != Slugify(f.Saga) {
+		return false
+	}
+
+	if len(f.Phases) > 0 {
+		var found bool
+		for _, phase := range f.Phases {
+			if movie.Phase == phase {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.PhaseFrom != 0 && movie.Phase < f.PhaseFrom {
+		return false
+	}
+	if f.PhaseTo != 0 && movie.Phase > f.PhaseTo {
+		return false
+	}
+
+	if f.Director != "" && !strings.Contains(strings.ToLower(movie.DirectedBy), strings.ToLower(f.Director)) {
+		return false
+	}
+
+	year := movie.ReleaseYear()
+	if f.ReleaseYearFrom != 0 && year < f.ReleaseYearFrom {
+		return false
+	}
+	if f.ReleaseYearTo != 0 && year > f.ReleaseYearTo {
+		return false
+	}
+
+	if movie.BoxOffice < f.MinBoxOffice {
+		return false
+	}
+
+	if f.HasPostCreditScenes != nil && (movie.PostCreditScenes > 0) != *f.HasPostCreditScenes {
+		return false
+	}
+
+	return true
+}
+
+// Filter returns the movies matching every criterion set on f.
+func (m Movies) Filter(f MovieFilter) Movies {
+	filtered := Movies{}
+	for _, movie := range m {
+		if f.matches(movie) {
+			filtered = append(filtered, movie)
+		}
+	}
+	return filtered
+}
+
+type MovieSortField string
+
+var (
+	MovieSortReleaseDate MovieSortField = "release_date"
+	MovieSortChronology  MovieSortField = "chronology"
+	MovieSortBoxOffice   MovieSortField = "box_office"
+	MovieSortDuration    MovieSortField = "duration"
+	MovieSortTitle       MovieSortField = "title"
+)
+
+var movieLess = map[MovieSortField]func(a, b *Movie) bool{
+	MovieSortReleaseDate: func(a, b *Movie) bool { return a.ReleaseDate.Before(b.ReleaseDate) },
+	MovieSortChronology:  func(a, b *Movie) bool { return a.Chronology < b.Chronology },
+	MovieSortBoxOffice:   func(a, b *Movie) bool { return a.BoxOffice < b.BoxOffice },
+	MovieSortDuration:    func(a, b *Movie) bool { return a.DurationMinutes < b.DurationMinutes },
+	MovieSortTitle:       func(a, b *Movie) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) },
+}
+
+// ValidMovieSortField reports whether movies can be sorted by field.
+func ValidMovieSortField(field MovieSortField) bool {
+	_, ok := movieLess[field]
+	return ok
+}
+
+// SortBy sorts movies by the given field, keeping the existing order of movies
+// that compare equal.
+func (m Movies) SortBy(field MovieSortField, order SortOrder) Movies {
+	less, ok := movieLess[field]
+	if !ok {
+		return m
+	}
+
+	switch order {
+	case SortAsc:
+		sort.SliceStable(m, func(i, j int) bool { return less(m[i], m[j]) })
+	case SortDesc:
+		sort.SliceStable(m, func(i, j int) bool { return less(m[j], m[i]) })
+	}
+	return m
+}
+
+// Paginate returns at most limit movies starting at offset. A limit of zero
+// returns every movie after offset.
+func (m Movies) Paginate(limit, offset int) Movies {
+	if offset >= len(m) {
+		return Movies{}
+	}
+
+	end := len(m)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return m[offset:end]
+}
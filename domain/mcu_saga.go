@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jace-ys/simple-api/internal/textmatch"
+)
+
+const maxSagaSuggestions = 3
+
+// SagaNotFoundError is returned when no saga matches a name. It matches
+// ErrSagaNotFound with errors.Is.
+type SagaNotFoundError struct {
+	Name string
+	// Suggestions are slugs of sagas with names close to Name.
+	Suggestions []string
+}
+
+func (e *SagaNotFoundError) Error() string {
+	return fmt.Sprintf("%s: %q", ErrSagaNotFound, e.Name)
+}
+
+func (e *SagaNotFoundError) Is(target error) bool {
+	return target == ErrSagaNotFound
+}
+
+// Slugify turns a saga name into a stable URL-safe identifier, such as
+// "infinity-saga" for "Infinity Saga". Accents and case are ignored.
+func Slugify(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range textmatch.Fold(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return sb.String()
+}
+
+// matchSaga finds the saga name that name refers to, either by slug or by a
+// unique slug prefix.
+func matchSaga(names []string, name string) (string, error) {
+	slug := Slugify(name)
+
+	var prefixed []string
+	for _, n := range names {
+		s := Slugify(n)
+		if s == slug {
+			return n, nil
+		}
+		if slug != "" && strings.HasPrefix(s, slug) {
+			prefixed = append(prefixed, n)
+		}
+	}
+
+	if len(prefixed) == 1 {
+		return prefixed[0], nil
+	}
+
+	return "", &SagaNotFoundError{
+		Name:        name,
+		Suggestions: suggestSagas(names, slug, prefixed),
+	}
+}
+
+// suggestSagas returns the slugs of any ambiguous prefix matches, or else of
+// the sagas within a few edits of slug, closest first.
+func suggestSagas(names []string, slug string, prefixed []string) []string {
+	suggestions := []string{}
+	if len(prefixed) > 0 {
+		for _, n := range prefixed {
+			suggestions = append(suggestions, Slugify(n))
+		}
+		sort.Strings(suggestions)
+		return suggestions
+	}
+
+	type candidate struct {
+		slug string
+		dist int
+	}
+
+	limit := max(2, len(slug)/3)
+	var candidates []candidate
+	for _, n := range names {
+		s := Slugify(n)
+		dist := textmatch.EditDistance(slug, s, limit)
+		// Also compare against the first word, so "infnity" is close to
+		// "infinity-saga".
+		if first, _, ok := strings.Cut(s, "-"); ok {
+			dist = min(dist, textmatch.EditDistance(slug, first, limit))
+		}
+		if dist <= limit {
+			candidates = append(candidates, candidate{slug: s, dist: dist})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].slug < candidates[j].slug
+	})
+
+	for i := 0; i < len(candidates) && i < maxSagaSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].slug)
+	}
+	return suggestions
+}
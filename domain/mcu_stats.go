@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+type YearlyBoxOffice struct {
+	Year           int `json:"year"`
+	TotalBoxOffice int `json:"total_box_office"`
+	TotalMovies    int `json:"total_movies"`
+}
+
+// BoxOfficeByYear totals box office by release year, earliest first. Movies
+// without a known release date are left out.
+func (m Movies) BoxOfficeByYear() []*YearlyBoxOffice {
+	ym := make(map[int]*YearlyBoxOffice)
+	for _, movie := range m {
+		year := movie.ReleaseYear()
+		if year == 0 {
+			continue
+		}
+		if ym[year] == nil {
+			ym[year] = &YearlyBoxOffice{Year: year}
+		}
+		ym[year].TotalBoxOffice += movie.BoxOffice
+		ym[year].TotalMovies++
+	}
+
+	years := make([]*YearlyBoxOffice, 0, len(ym))
+	for _, y := range ym {
+		years = append(years, y)
+	}
+
+	sort.Slice(years, func(i, j int) bool { return years[i].Year < years[j].Year })
+	return years
+}
+
+type CumulativeBoxOffice struct {
+	MovieID             int    `json:"movie_id"`
+	Title               string `json:"title"`
+	ReleaseDate         Date   `json:"release_date"`
+	BoxOffice           int    `json:"box_office"`
+	CumulativeBoxOffice int    `json:"cumulative_box_office"`
+}
+
+// CumulativeBoxOffice returns the running box office total as each movie was
+// released. Movies without a known release date are left out.
+func (m Movies) CumulativeBoxOffice() []*CumulativeBoxOffice {
+	released := make(Movies, 0, len(m))
+	for _, movie := range m {
+		if !movie.ReleaseDate.IsZero() {
+			released = append(released, movie)
+		}
+	}
+	released = released.WatchOrder(WatchOrderRelease)
+
+	var total int
+	cumulative := make([]*CumulativeBoxOffice, 0, len(released))
+	for _, movie := range released {
+		total += movie.BoxOffice
+		cumulative = append(cumulative, &CumulativeBoxOffice{
+			MovieID:             movie.ID,
+			Title:               movie.Title,
+			ReleaseDate:         movie.ReleaseDate,
+			BoxOffice:           movie.BoxOffice,
+			CumulativeBoxOffice: total,
+		})
+	}
+
+	return cumulative
+}
+
+type BoxOfficePerMinute struct {
+	MovieID            int     `json:"movie_id"`
+	Title              string  `json:"title"`
+	BoxOffice          int     `json:"box_office"`
+	DurationMinutes    int     `json:"duration_minutes"`
+	BoxOfficePerMinute float64 `json:"box_office_per_minute"`
+}
+
+// BoxOfficePerMinute ranks movies by box office earned per minute of runtime,
+// highest first. Movies without a known runtime are left out.
+func (m Movies) BoxOfficePerMinute() []*BoxOfficePerMinute {
+	ranked := make([]*BoxOfficePerMinute, 0, len(m))
+	for _, movie := range m {
+		if movie.DurationMinutes <= 0 {
+			continue
+		}
+		ranked = append(ranked, &BoxOfficePerMinute{
+			MovieID:            movie.ID,
+			Title:              movie.Title,
+			BoxOffice:          movie.BoxOffice,
+			DurationMinutes:    movie.DurationMinutes,
+			BoxOfficePerMinute: math.Round(float64(movie.BoxOffice)/float64(movie.DurationMinutes)*100) / 100,
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].BoxOfficePerMinute > ranked[j].BoxOfficePerMinute
+	})
+	return ranked
+}
+
+type PhaseTopGrossing struct {
+	Phase  int    `json:"phase"`
+	Movies Movies `json:"movies"`
+}
+
+// TopGrossingByPhase returns the n highest grossing movies of each phase.
+func (m Movies) TopGrossingByPhase(n int) []*PhaseTopGrossing {
+	phases := m.GroupByPhase()
+
+	top := make([]*PhaseTopGrossing, 0, len(phases))
+	for _, phase := range phases {
+		movies := make(Movies, len(phase.Movies))
+		copy(movies, phase.Movies)
+		movies.SortBy(MovieSortBoxOffice, SortDesc)
+
+		top = append(top, &PhaseTopGrossing{
+			Phase:  phase.Number,
+			Movies: movies.Paginate(n, 0),
+		})
+	}
+
+	return top
+}
+
+type SagaYearOverYear struct {
+	Saga           string `json:"saga"`
+	Year           int    `json:"year"`
+	TotalBoxOffice int    `json:"total_box_office"`
+	TotalMovies    int    `json:"total_movies"`
+	// PreviousYear is the saga's previous release year, if any.
+	PreviousYear int `json:"previous_year,omitempty"`
+	// ChangePercent is the change in box office from PreviousYear.
+	ChangePercent *float64 `json:"change_percent"`
+}
+
+// SagaYearOverYear compares each saga's box office across the years it had
+// releases in, ordered by saga start date and then year.
+func (m Movies) SagaYearOverYear() []*SagaYearOverYear {
+	comparisons := []*SagaYearOverYear{}
+	for _, saga := range m.GroupBySaga() {
+		var sagaMovies Movies
+		for _, phase := range saga.Phases {
+			sagaMovies = append(sagaMovies, phase.Movies...)
+		}
+
+		var prev *YearlyBoxOffice
+		for _, year := range sagaMovies.BoxOfficeByYear() {
+			c := &SagaYearOverYear{
+				Saga:           saga.Name,
+				Year:           year.Year,
+				TotalBoxOffice: year.TotalBoxOffice,
+				TotalMovies:    year.TotalMovies,
+			}
+			if prev != nil {
+				c.PreviousYear = prev.Year
+				if prev.TotalBoxOffice != 0 {
+					change := math.Round(float64(year.TotalBoxOffice-prev.TotalBoxOffice)/float64(prev.TotalBoxOffice)*10000) / 100
+					c.ChangePercent = &change
+				}
+			}
+
+			comparisons = append(comparisons, c)
+			prev = year
+		}
+	}
+
+	return comparisons
+}
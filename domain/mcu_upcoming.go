@@ -0,0 +1,32 @@
+package domain
+
+import "sort"
+
+type UpcomingRelease struct {
+	Movie *Movie `json:"movie"`
+	// DaysUntilRelease is nil when the release date hasn't been announced.
+	DaysUntilRelease *int `json:"days_until_release"`
+}
+
+// Upcoming returns the movies that haven't been released as of today,
+// soonest first. Movies without an announced release date come last.
+func (m Movies) Upcoming(today Date) []*UpcomingRelease {
+	upcoming := []*UpcomingRelease{}
+	for _, movie := range m {
+		if !movie.IsUpcoming(today) {
+			continue
+		}
+
+		release := &UpcomingRelease{Movie: movie}
+		if !movie.ReleaseDate.IsZero() {
+			days := movie.ReleaseDate.DaysSince(today)
+			release.DaysUntilRelease = &days
+		}
+		upcoming = append(upcoming, release)
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].Movie.ReleaseDate.Before(upcoming[j].Movie.ReleaseDate)
+	})
+	return upcoming
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var ErrInvalidMarathonPlan = errors.New("invalid marathon plan")
+
+type WatchOrder string
+
+var (
+	WatchOrderChronological WatchOrder = "chronological"
+	WatchOrderRelease       WatchOrder = "release"
+)
+
+// WatchOrder returns a copy of the movies in in-universe or release order.
+func (m Movies) WatchOrder(order WatchOrder) Movies {
+	ordered := make(Movies, len(m))
+	copy(ordered, m)
+
+	switch order {
+	case WatchOrderChronological:
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Chronology < ordered[j].Chronology })
+	case WatchOrderRelease:
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].ReleaseDate.Before(ordered[j].ReleaseDate) })
+	}
+
+	return ordered
+}
+
+type MarathonPlan struct {
+	// Start is when the first movie starts. Every following day starts at the
+	// same time of day, or once the previous day's last break is over if that's
+	// later.
+	Start time.Time
+	// DailyViewing is how long a day's viewing lasts, counting the breaks
+	// between its movies. A movie longer than this gets a day to itself.
+	DailyViewing time.Duration
+	// Break is the gap left between consecutive movies on the same day.
+	Break time.Duration
+}
+
+type Marathon struct {
+	Start        time.Time      `json:"start"`
+	End          time.Time      `json:"end"`
+	TotalMovies  int            `json:"total_movies"`
+	TotalMinutes int            `json:"total_minutes"`
+	Days         []*MarathonDay `json:"days"`
+}
+
+type MarathonDay struct {
+	Day          int                `json:"day"`
+	Date         string             `json:"date"`
+	WatchMinutes int                `json:"watch_minutes"`
+	Sessions     []*MarathonSession `json:"sessions"`
+}
+
+type MarathonSession struct {
+	Movie *Movie    `json:"movie"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// PlanMarathon schedules the movies, in the order given, into days of at most
+// p.DailyViewing each.
+func (m Movies) PlanMarathon(p MarathonPlan) (*Marathon, error) {
+	if p.DailyViewing <= 0 || p.Break < 0 || p.Start.IsZero() {
+		return nil, ErrInvalidMarathonPlan
+	}
+
+	marathon := &Marathon{
+		Start: p.Start,
+		End:   p.Start,
+		Days:  []*MarathonDay{},
+	}
+
+	var day *MarathonDay
+	var dayStart, cursor time.Time
+
+	newDay := func() {
+		n := len(marathon.Days)
+		dayStart = p.Start.AddDate(0, 0, n)
+		if cursor.After(dayStart) {
+			dayStart = cursor
+		}
+		day = &MarathonDay{
+			Day:      n + 1,
+			Date:     dayStart.Format("2006-01-02"),
+			Sessions: []*MarathonSession{},
+		}
+		marathon.Days = append(marathon.Days, day)
+		cursor = dayStart
+	}
+
+	for _, movie := range m {
+		duration := time.Duration(movie.DurationMinutes) * time.Minute
+		if day == nil || (cursor.After(dayStart) && cursor.Add(duration).Sub(dayStart) > p.DailyViewing) {
+			newDay()
+		}
+
+		session := &MarathonSession{
+			Movie: movie,
+			Start: cursor,
+			End:   cursor.Add(duration),
+		}
+		day.Sessions = append(day.Sessions, session)
+		day.WatchMinutes += movie.DurationMinutes
+
+		marathon.End = session.End
+		marathon.TotalMovies++
+		marathon.TotalMinutes += movie.DurationMinutes
+
+		cursor = session.End.Add(p.Break)
+	}
+
+	return marathon, nil
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+var ErrReviewNotFound = errors.New("review not found")
+
+const (
+	MinRating           = 1
+	MaxRating           = 10
+	MaxReviewTextLength = 1000
+)
+
+type ModerationStatus string
+
+var (
+	// ModerationPublished reviews are shown and count towards ratings.
+	ModerationPublished ModerationStatus = "published"
+	// ModerationFlagged reviews have been reported by other users but are
+	// still shown until a moderator hides or republishes them.
+	ModerationFlagged ModerationStatus = "flagged"
+	// ModerationHidden reviews are neither shown nor counted.
+	ModerationHidden ModerationStatus = "hidden"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ReviewStore
+type ReviewStore interface {
+	RatingsService
+	// GetReviews returns every review of a movie, newest first, including
+	// hidden ones.
+	GetReviews(ctx context.Context, movieID int) ([]*Review, error)
+	GetReview(ctx context.Context, movieID int, userID string) (*Review, error)
+	// PutReview creates or replaces the user's review of a movie, keeping its
+	// creation time and moderation state. It reports whether it was created.
+	PutReview(ctx context.Context, review *Review) (*Review, bool, error)
+	DeleteReview(ctx context.Context, movieID int, userID string) error
+	// FlagReview records a report against a review and marks it as flagged,
+	// unless it's already hidden.
+	FlagReview(ctx context.Context, movieID int, userID string) (*Review, error)
+	SetModeration(ctx context.Context, movieID int, userID string, status ModerationStatus) (*Review, error)
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . RatingsService
+type RatingsService interface {
+	// GetRatings returns the rating of every movie with visible reviews, by
+	// movie ID.
+	GetRatings(ctx context.Context) (map[int]*Rating, error)
+}
+
+type Review struct {
+	MovieID    int              `json:"movie_id"`
+	UserID     string           `json:"user_id"`
+	Rating     int              `json:"rating"`
+	Text       string           `json:"text"`
+	Moderation ModerationStatus `json:"moderation"`
+	Flags      int              `json:"flags"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// Visible reports whether the review is shown and counts towards ratings.
+func (r *Review) Visible() bool {
+	return r.Moderation != ModerationHidden
+}
+
+type Rating struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+// NewRating returns the rating of a movie with count visible reviews whose
+// ratings add up to sum.
+func NewRating(sum, count int) *Rating {
+	return &Rating{
+		Average: math.Round(float64(sum)/float64(count)*100) / 100,
+		Count:   count,
+	}
+}
+
+// WithRatings returns copies of the movies with their rating and review count
+// set from ratings.
+func (m Movies) WithRatings(ratings map[int]*Rating) Movies {
+	rated := make(Movies, len(m))
+	for i, movie := range m {
+		rated[i] = movie.WithRating(ratings[movie.ID])
+	}
+	return rated
+}
+
+// WithRating returns a copy of the movie with its rating and review count set
+// from rating, which may be nil if it has no reviews.
+func (m *Movie) WithRating(rating *Rating) *Movie {
+	rated := *m
+	rated.Rating, rated.ReviewCount = 0, 0
+	if rating != nil {
+		rated.Rating = rating.Average
+		rated.ReviewCount = rating.Count
+	}
+	return &rated
+}
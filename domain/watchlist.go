@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+var ErrWatchlistEntryNotFound = errors.New("watchlist entry not found")
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . WatchlistStore
+type WatchlistStore interface {
+	GetWatchlist(ctx context.Context, userID string) (Watchlist, error)
+	// AddToWatchlist adds a movie to the user's watchlist. Adding a movie
+	// that's already on it returns the existing entry.
+	AddToWatchlist(ctx context.Context, userID string, movieID int, addedAt time.Time) (*WatchlistEntry, error)
+	RemoveFromWatchlist(ctx context.Context, userID string, movieID int) error
+	// SetWatched records when the user watched a movie on their watchlist,
+	// or clears it if watchedAt is nil.
+	SetWatched(ctx context.Context, userID string, movieID int, watchedAt *time.Time) (*WatchlistEntry, error)
+}
+
+// Watchlist is a user's watchlist, ordered by when movies were added.
+type Watchlist []*WatchlistEntry
+
+type WatchlistEntry struct {
+	MovieID   int        `json:"movie_id"`
+	AddedAt   time.Time  `json:"added_at"`
+	WatchedAt *time.Time `json:"watched_at"`
+}
+
+func (w Watchlist) watched() map[int]bool {
+	watched := make(map[int]bool)
+	for _, entry := range w {
+		if entry.WatchedAt != nil {
+			watched[entry.MovieID] = true
+		}
+	}
+	return watched
+}
+
+type WatchProgress struct {
+	Watched          int     `json:"watched"`
+	Total            int     `json:"total"`
+	Percent          float64 `json:"percent"`
+	WatchedMinutes   int     `json:"watched_minutes"`
+	RemainingMinutes int     `json:"remaining_minutes"`
+}
+
+type SagaProgress struct {
+	Saga string `json:"saga"`
+	Slug string `json:"slug"`
+	WatchProgress
+	Phases []*PhaseProgress `json:"phases"`
+}
+
+type PhaseProgress struct {
+	Phase int `json:"phase"`
+	WatchProgress
+}
+
+// Progress reports how much of each saga and phase the watchlist has marked
+// as watched, out of every movie in it.
+func (m Movies) Progress(w Watchlist) []*SagaProgress {
+	watched := w.watched()
+
+	progress := []*SagaProgress{}
+	for _, saga := range m.GroupBySaga() {
+		sp := &SagaProgress{
+			Saga: saga.Name,
+			Slug: saga.Slug,
+		}
+
+		for _, phase := range saga.Phases {
+			pp := &PhaseProgress{
+				Phase:         phase.Number,
+				WatchProgress: phase.Movies.watchProgress(watched),
+			}
+			sp.Phases = append(sp.Phases, pp)
+
+			sp.Watched += pp.Watched
+			sp.Total += pp.Total
+			sp.WatchedMinutes += pp.WatchedMinutes
+			sp.RemainingMinutes += pp.RemainingMinutes
+		}
+		sp.Percent = percent(sp.Watched, sp.Total)
+
+		progress = append(progress, sp)
+	}
+
+	return progress
+}
+
+func (m Movies) watchProgress(watched map[int]bool) WatchProgress {
+	var p WatchProgress
+	for _, movie := range m {
+		p.Total++
+		if watched[movie.ID] {
+			p.Watched++
+			p.WatchedMinutes += movie.DurationMinutes
+		} else {
+			p.RemainingMinutes += movie.DurationMinutes
+		}
+	}
+	p.Percent = percent(p.Watched, p.Total)
+	return p
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*10000) / 100
+}
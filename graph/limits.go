@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listCost is the assumed size of lists when estimating query complexity.
+const listCost = 10
+
+// Limits bounds the queries that will be executed.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields allowed.
+	MaxDepth int
+	// MaxComplexity is the highest estimated cost allowed. Each field costs
+	// one, and the fields selected under a list are counted listCost times.
+	MaxComplexity int
+}
+
+func DefaultLimits() Limits {
+	return Limits{
+		MaxDepth:      6,
+		MaxComplexity: 5000,
+	}
+}
+
+// check reports an error if the operation in doc exceeds the limits.
+func (l Limits) check(schema graphql.Schema, doc *ast.Document) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		root := schema.QueryType()
+		if op.Operation == ast.OperationTypeMutation {
+			root = schema.MutationType()
+		}
+
+		w := &walker{fragments: fragments, visiting: make(map[string]bool)}
+		depth, cost := w.walk(op.SelectionSet, root)
+		switch {
+		case l.MaxDepth > 0 && depth > l.MaxDepth:
+			return fmt.Errorf("query depth %d exceeds the maximum of %d", depth, l.MaxDepth)
+		case l.MaxComplexity > 0 && cost > l.MaxComplexity:
+			return fmt.Errorf("query complexity %d exceeds the maximum of %d", cost, l.MaxComplexity)
+		}
+	}
+
+	return nil
+}
+
+type walker struct {
+	fragments map[string]*ast.FragmentDefinition
+	visiting  map[string]bool
+}
+
+// walk returns the depth and cost of the selections made on parent.
+func (w *walker) walk(set *ast.SelectionSet, parent *graphql.Object) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	var depth, cost int
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			d, c = w.field(s, parent)
+		case *ast.InlineFragment:
+			d, c = w.walk(s.SelectionSet, parent)
+		case *ast.FragmentSpread:
+			// Cycles are rejected by validation, but are guarded against here
+			// since limits are checked first.
+			name := s.Name.Value
+			fragment, ok := w.fragments[name]
+			if !ok || w.visiting[name] {
+				continue
+			}
+			w.visiting[name] = true
+			d, c = w.walk(fragment.SelectionSet, parent)
+			w.visiting[name] = false
+		}
+		depth = max(depth, d)
+		cost += c
+	}
+
+	return depth, cost
+}
+
+func (w *walker) field(field *ast.Field, parent *graphql.Object) (int, int) {
+	if parent == nil || strings.HasPrefix(field.Name.Value, "__") {
+		return 1, 1
+	}
+
+	def, ok := parent.Fields()[field.Name.Value]
+	if !ok {
+		return 1, 1
+	}
+
+	multiplier := 1
+	t := def.Type
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	if list, ok := t.(*graphql.List); ok {
+		multiplier = listCost
+		t = list.OfType
+	}
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+
+	child, _ := t.(*graphql.Object)
+	depth, cost := w.walk(field.SelectionSet, child)
+	return 1 + depth, 1 + multiplier*cost
+}
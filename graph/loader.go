@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/logging"
+)
+
+// movieLoader batches the movie lookups made while resolving one level of a
+// query. Lookups return thunks, and the first thunk to be resolved loads
+// every movie requested so far in a single call.
+type movieLoader struct {
+	movies domain.MoviesService
+
+	mu      sync.Mutex
+	pending []int
+	loaded  map[int]*domain.Movie
+	errs    map[int]error
+}
+
+func newMovieLoader(movies domain.MoviesService) *movieLoader {
+	return &movieLoader{
+		movies: movies,
+		loaded: make(map[int]*domain.Movie),
+		errs:   make(map[int]error),
+	}
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, loader *movieLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, loader)
+}
+
+func loaderFromContext(ctx context.Context) *movieLoader {
+	return ctx.Value(loaderKey{}).(*movieLoader)
+}
+
+// Load queues movieID to be loaded, returning a thunk that resolves to the
+// movie, or nil if it doesn't exist.
+func (l *movieLoader) Load(ctx context.Context, movieID int) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.loaded[movieID]; !ok {
+		l.pending = append(l.pending, movieID)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.flush(ctx)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if err := l.errs[movieID]; err != nil {
+			return nil, err
+		}
+		if movie := l.loaded[movieID]; movie != nil {
+			return movie, nil
+		}
+		return nil, nil
+	}
+}
+
+// flush loads the pending movies. A single movie is fetched on its own, and
+// any more are served from one call for the full list. Errors are recorded
+// against every movie in the batch.
+func (l *movieLoader) flush(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var pending []int
+	for _, id := range l.pending {
+		if _, ok := l.loaded[id]; !ok {
+			l.loaded[id] = nil
+			pending = append(pending, id)
+		}
+	}
+	l.pending = nil
+
+	switch len(pending) {
+	case 0:
+	case 1:
+		movie, err := l.movies.GetMovie(ctx, pending[0])
+		switch {
+		case errors.Is(err, domain.ErrMovieNotFound):
+		case err != nil:
+			logging.FromContext(ctx).Error("GetMovie request error", slog.Any("error", err), slog.Int("movie_id", pending[0]))
+			l.errs[pending[0]] = errInternal
+		default:
+			l.loaded[movie.ID] = movie
+		}
+	default:
+		movies, err := l.movies.GetMovies(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Error("GetMovies request error", slog.Any("error", err))
+			for _, id := range pending {
+				l.errs[id] = errInternal
+			}
+			return
+		}
+		for _, movie := range movies {
+			if _, ok := l.loaded[movie.ID]; ok {
+				l.loaded[movie.ID] = movie
+			}
+		}
+	}
+}
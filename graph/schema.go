@@ -0,0 +1,217 @@
+// Package graph serves movies, sagas and flights over GraphQL, with a schema
+// derived from the domain types.
+package graph
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/logging"
+)
+
+// errInternal is returned in place of errors from services, which are logged
+// rather than exposed to clients.
+var errInternal = errors.New("internal server error")
+
+type Schema struct {
+	schema  graphql.Schema
+	movies  domain.MoviesService
+	flights []domain.FlightsService
+	limits  Limits
+}
+
+// NewSchema returns a schema resolving movies and sagas from movies, and
+// flights from every flights service.
+func NewSchema(movies domain.MoviesService, flights []domain.FlightsService, limits Limits) (*Schema, error) {
+	s := &Schema{
+		movies:  movies,
+		flights: flights,
+		limits:  limits,
+	}
+
+	types := newObjects()
+	movieType := types.object("Movie", (*domain.Movie)(nil), graphql.Fields{
+		"upcoming": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Boolean),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*domain.Movie).IsUpcoming(domain.Today()), nil
+			},
+		},
+	})
+	types.object("Phase", (*domain.Phase)(nil), nil)
+	sagaType := types.object("Saga", (*domain.Saga)(nil), nil)
+	flightType := types.object("Flight", (*domain.DuffelFlight)(nil), nil)
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"movies": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(movieType))),
+				Args: graphql.FieldConfigArgument{
+					"saga":  &graphql.ArgumentConfig{Type: graphql.String},
+					"phase": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: s.resolveMovies,
+			},
+			"movie": &graphql.Field{
+				Type: movieType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loaderFromContext(p.Context).Load(p.Context, p.Args["id"].(int)), nil
+				},
+			},
+			"movies_by_id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(movieType)),
+				Args: graphql.FieldConfigArgument{
+					"ids": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					loader := loaderFromContext(p.Context)
+					var thunks []interface{}
+					for _, id := range p.Args["ids"].([]interface{}) {
+						thunks = append(thunks, loader.Load(p.Context, id.(int)))
+					}
+					return thunks, nil
+				},
+			},
+			"sagas": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(sagaType))),
+				Resolve: s.resolveSagas,
+			},
+			"saga": &graphql.Field{
+				Type: sagaType,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.resolveSaga,
+			},
+			"flights": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(flightType))),
+				Args: graphql.FieldConfigArgument{
+					"origin":         &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"destination":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"departure_date": &graphql.ArgumentConfig{Type: graphql.NewNonNull(dateScalar)},
+				},
+				Resolve: s.resolveFlights,
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+
+	return s, nil
+}
+
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Execute parses, validates and runs a request. Queries that exceed the
+// schema's limits are rejected before they're executed.
+func (s *Schema) Execute(ctx context.Context, req Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if err := s.limits.check(s.schema, doc); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := graphql.ValidateDocument(&s.schema, doc, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoader(ctx, newMovieLoader(s.movies)),
+	})
+}
+
+func (s *Schema) getMovies(ctx context.Context) (domain.Movies, error) {
+	movies, err := s.movies.GetMovies(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("GetMovies request error", slog.Any("error", err))
+		return nil, errInternal
+	}
+	return movies, nil
+}
+
+func (s *Schema) resolveMovies(p graphql.ResolveParams) (interface{}, error) {
+	movies, err := s.getMovies(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter domain.MovieFilter
+	if saga, ok := p.Args["saga"].(string); ok {
+		filter.Saga = saga
+	}
+	if phase, ok := p.Args["phase"].(int); ok {
+		filter.Phases = []int{phase}
+	}
+
+	return movies.Filter(filter), nil
+}
+
+func (s *Schema) resolveSagas(p graphql.ResolveParams) (interface{}, error) {
+	movies, err := s.getMovies(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	return movies.GroupBySaga(), nil
+}
+
+func (s *Schema) resolveSaga(p graphql.ResolveParams) (interface{}, error) {
+	movies, err := s.getMovies(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	return movies.GetSaga(p.Args["name"].(string))
+}
+
+// resolveFlights searches every flights service, only failing if none of them
+// succeed. Unlike the REST endpoint, finding no flights isn't an error, so an
+// empty list is returned when every service succeeds without any.
+func (s *Schema) resolveFlights(p graphql.ResolveParams) (interface{}, error) {
+	departureDate, ok := p.Args["departure_date"].(domain.Date)
+	if !ok || departureDate.IsZero() {
+		return nil, errors.New("invalid departure date, must be of format YYYY-MM-DD")
+	}
+	origin, destination := p.Args["origin"].(string), p.Args["destination"].(string)
+
+	flights := domain.DuffelFlights{}
+	var failed bool
+	for _, service := range s.flights {
+		results, err := service.GetFlights(p.Context, origin, destination, departureDate.String())
+		if err != nil {
+			logging.FromContext(p.Context).Error("GetFlights request error", slog.Any("error", err))
+			failed = true
+			continue
+		}
+		flights = append(flights, results...)
+	}
+
+	if len(flights) == 0 && failed {
+		return nil, errInternal
+	}
+	return flights.SortByPrice(domain.SortAsc), nil
+}
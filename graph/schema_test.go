@@ -0,0 +1,220 @@
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/graph"
+)
+
+var testMovies = domain.Movies{
+	{ID: 1, Title: "Iron Man", ReleaseDate: domain.MustParseDate("2008-05-02"), DurationMinutes: 126, Phase: 1, Saga: "Infinity Saga"},
+	{ID: 2, Title: "The Incredible Hulk", ReleaseDate: domain.MustParseDate("2008-06-13"), DurationMinutes: 112, Phase: 1, Saga: "Infinity Saga"},
+	{ID: 3, Title: "Iron Man 2", ReleaseDate: domain.MustParseDate("2010-05-07"), DurationMinutes: 124, Phase: 1, Saga: "Infinity Saga"},
+	{ID: 4, Title: "Black Widow", ReleaseDate: domain.MustParseDate("2021-07-09"), DurationMinutes: 134, Phase: 4, Saga: "Multiverse Saga"},
+}
+
+func TestExecute(t *testing.T) {
+	tt := []struct {
+		Name          string
+		Request       graph.Request
+		Limits        graph.Limits
+		MoviesErr     error
+		ExpectedData  string
+		ExpectedError string
+	}{
+		{
+			Name:         "Nested",
+			Request:      graph.Request{Query: `{ saga(name: "infinity-saga") { name phases { number movies { title duration_minutes } } } }`},
+			ExpectedData: `{"saga":{"name":"Infinity Saga","phases":[{"number":1,"movies":[{"title":"Iron Man","duration_minutes":126},{"title":"The Incredible Hulk","duration_minutes":112},{"title":"Iron Man 2","duration_minutes":124}]}]}}`,
+		},
+		{
+			Name:         "Filtered",
+			Request:      graph.Request{Query: `query($phase: Int) { movies(phase: $phase) { id release_date } }`, Variables: map[string]interface{}{"phase": 4}},
+			ExpectedData: `{"movies":[{"id":4,"release_date":"2021-07-09"}]}`,
+		},
+		{
+			Name:         "Aggregates",
+			Request:      graph.Request{Query: `{ sagas { name start_date end_date } }`},
+			ExpectedData: `{"sagas":[{"name":"Infinity Saga","start_date":"2008-05-02","end_date":"2010-05-07"},{"name":"Multiverse Saga","start_date":"2021-07-09","end_date":"2021-07-09"}]}`,
+		},
+		{
+			Name:          "SagaNotFound",
+			Request:       graph.Request{Query: `{ saga(name: "unknown") { name } }`},
+			ExpectedData:  `{"saga":null}`,
+			ExpectedError: `saga not found: "unknown"`,
+		},
+		{
+			Name:          "ServiceError",
+			Request:       graph.Request{Query: `{ movies { id } }`},
+			MoviesErr:     errors.New("upstream error"),
+			ExpectedData:  `null`,
+			ExpectedError: "internal server error",
+		},
+		{
+			Name:          "UnknownField",
+			Request:       graph.Request{Query: `{ movies { budget } }`},
+			ExpectedData:  `null`,
+			ExpectedError: `Cannot query field "budget" on type "Movie".`,
+		},
+		{
+			Name:          "TooDeep",
+			Request:       graph.Request{Query: `{ sagas { phases { movies { title } } } }`},
+			Limits:        graph.Limits{MaxDepth: 3},
+			ExpectedData:  `null`,
+			ExpectedError: "query depth 4 exceeds the maximum of 3",
+		},
+		{
+			Name:          "TooComplex",
+			Request:       graph.Request{Query: `{ a: movies { id title } b: movies { id title } }`},
+			Limits:        graph.Limits{MaxComplexity: 40},
+			ExpectedData:  `null`,
+			ExpectedError: "query complexity 42 exceeds the maximum of 40",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			movies := new(domainfakes.FakeMoviesService)
+			movies.GetMoviesReturns(testMovies, tc.MoviesErr)
+
+			schema, err := graph.NewSchema(movies, nil, tc.Limits)
+			assert.NoError(t, err)
+
+			res := schema.Execute(context.Background(), tc.Request)
+
+			data, err := json.Marshal(res.Data)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.ExpectedData, string(data))
+
+			if tc.ExpectedError == "" {
+				assert.Empty(t, res.Errors)
+			} else if assert.Len(t, res.Errors, 1) {
+				assert.Equal(t, tc.ExpectedError, res.Errors[0].Message)
+			}
+		})
+	}
+}
+
+func TestExecuteBatchesMovieLookups(t *testing.T) {
+	tt := []struct {
+		Name               string
+		Query              string
+		ExpectedData       string
+		ExpectedGetMovie   int
+		ExpectedGetMovies  int
+		ExpectedGetMovieID int
+	}{
+		{
+			Name:               "Single",
+			Query:              `{ movie(id: 2) { title } }`,
+			ExpectedData:       `{"movie":{"title":"The Incredible Hulk"}}`,
+			ExpectedGetMovie:   1,
+			ExpectedGetMovieID: 2,
+		},
+		{
+			Name:              "Aliases",
+			Query:             `{ a: movie(id: 1) { title } b: movie(id: 3) { title } c: movie(id: 1) { id } }`,
+			ExpectedData:      `{"a":{"title":"Iron Man"},"b":{"title":"Iron Man 2"},"c":{"id":1}}`,
+			ExpectedGetMovies: 1,
+		},
+		{
+			Name:              "ByID",
+			Query:             `{ movies_by_id(ids: [4, 99, 1]) { id } }`,
+			ExpectedData:      `{"movies_by_id":[{"id":4},null,{"id":1}]}`,
+			ExpectedGetMovies: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			movies := new(domainfakes.FakeMoviesService)
+			movies.GetMoviesReturns(testMovies, nil)
+			movies.GetMovieReturns(testMovies[1], nil)
+
+			schema, err := graph.NewSchema(movies, nil, graph.DefaultLimits())
+			assert.NoError(t, err)
+
+			res := schema.Execute(context.Background(), graph.Request{Query: tc.Query})
+			assert.Empty(t, res.Errors)
+
+			data, err := json.Marshal(res.Data)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.ExpectedData, string(data))
+
+			assert.Equal(t, tc.ExpectedGetMovie, movies.GetMovieCallCount())
+			assert.Equal(t, tc.ExpectedGetMovies, movies.GetMoviesCallCount())
+			if tc.ExpectedGetMovie > 0 {
+				_, movieID := movies.GetMovieArgsForCall(0)
+				assert.Equal(t, tc.ExpectedGetMovieID, movieID)
+			}
+		})
+	}
+}
+
+func TestExecuteFlights(t *testing.T) {
+	departure := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	airlineA := new(domainfakes.FakeFlightsService)
+	airlineA.GetFlightsReturns(domain.DuffelFlights{
+		{FlightNumber: "A1", TotalAmount: 250, Currency: "GBP", DepartureTime: departure},
+	}, nil)
+	airlineB := new(domainfakes.FakeFlightsService)
+	airlineB.GetFlightsReturns(domain.DuffelFlights{
+		{FlightNumber: "B1", TotalAmount: 120, Currency: "GBP", DepartureTime: departure},
+	}, nil)
+	airlineC := new(domainfakes.FakeFlightsService)
+	airlineC.GetFlightsReturns(nil, errors.New("upstream error"))
+
+	movies := new(domainfakes.FakeMoviesService)
+	movies.GetMovieReturns(testMovies[1], nil)
+
+	schema, err := graph.NewSchema(movies, []domain.FlightsService{airlineA, airlineB, airlineC}, graph.DefaultLimits())
+	assert.NoError(t, err)
+
+	query := `{
+		flights(origin: "LHR", destination: "JFK", departure_date: "2025-03-01") { flight_number total_amount departure_time }
+		movie(id: 2) { title }
+	}`
+	res := schema.Execute(context.Background(), graph.Request{Query: query})
+	assert.Empty(t, res.Errors)
+
+	data, err := json.Marshal(res.Data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"flights": [
+			{"flight_number": "B1", "total_amount": 120, "departure_time": "2025-03-01T09:00:00Z"},
+			{"flight_number": "A1", "total_amount": 250, "departure_time": "2025-03-01T09:00:00Z"}
+		],
+		"movie": {"title": "The Incredible Hulk"}
+	}`, string(data))
+
+	_, origin, destination, departureDate := airlineA.GetFlightsArgsForCall(0)
+	assert.Equal(t, "LHR", origin)
+	assert.Equal(t, "JFK", destination)
+	assert.Equal(t, "2025-03-01", departureDate)
+
+	invalid := `{ flights(origin: "LHR", destination: "JFK", departure_date: "next week") { flight_number } }`
+	res = schema.Execute(context.Background(), graph.Request{Query: invalid})
+	assert.NotEmpty(t, res.Errors)
+
+	empty := new(domainfakes.FakeFlightsService)
+	empty.GetFlightsReturns(domain.DuffelFlights{}, nil)
+
+	schema, err = graph.NewSchema(movies, []domain.FlightsService{empty}, graph.DefaultLimits())
+	assert.NoError(t, err)
+
+	res = schema.Execute(context.Background(), graph.Request{Query: `{ flights(origin: "LHR", destination: "JFK", departure_date: "2025-03-01") { flight_number } }`})
+	assert.Empty(t, res.Errors, "finding no flights isn't an error")
+
+	data, err = json.Marshal(res.Data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"flights": []}`, string(data))
+}
@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+// dateScalar serializes a domain.Date as YYYY-MM-DD, or null if it's unknown.
+var dateScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Date",
+	Description: "A calendar date formatted as YYYY-MM-DD.",
+	Serialize: func(value interface{}) interface{} {
+		d, ok := value.(domain.Date)
+		if !ok || d.IsZero() {
+			return nil
+		}
+		return d.String()
+	},
+	ParseValue: func(value interface{}) interface{} {
+		s, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		d, err := domain.ParseDate(s)
+		if err != nil {
+			return nil
+		}
+		return d
+	},
+	ParseLiteral: func(value ast.Value) interface{} {
+		s, ok := value.(*ast.StringValue)
+		if !ok {
+			return nil
+		}
+		d, err := domain.ParseDate(s.Value)
+		if err != nil {
+			return nil
+		}
+		return d
+	},
+})
+
+var (
+	dateType = reflect.TypeOf(domain.Date{})
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// objects builds GraphQL object types from domain structs, naming fields by
+// their graphql tag, falling back to their json tag. Embedded structs are
+// flattened like they are in JSON.
+type objects struct {
+	types map[reflect.Type]*graphql.Object
+}
+
+func newObjects() *objects {
+	return &objects{types: make(map[reflect.Type]*graphql.Object)}
+}
+
+// object returns the object type for the struct v points to, building it if
+// needed. extra fields are added to those derived from the struct.
+func (o *objects) object(name string, v interface{}, extra graphql.Fields) *graphql.Object {
+	t := reflect.TypeOf(v).Elem()
+	if obj, ok := o.types[t]; ok {
+		return obj
+	}
+
+	fields := graphql.Fields{}
+	o.addFields(fields, t, nil)
+	for fieldName, field := range extra {
+		fields[fieldName] = field
+	}
+
+	obj := graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: fields})
+	o.types[t] = obj
+	return obj
+}
+
+func (o *objects) addFields(fields graphql.Fields, t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		path := append(append([]int{}, index...), i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			o.addFields(fields, sf.Type, path)
+			continue
+		}
+
+		name := fieldName(sf)
+		if name == "" {
+			continue
+		}
+
+		fields[name] = &graphql.Field{
+			Type:    o.outputType(sf.Type),
+			Resolve: resolveField(path),
+		}
+	}
+}
+
+func fieldName(sf reflect.StructField) string {
+	for _, tag := range []string{"graphql", "json"} {
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+func (o *objects) outputType(t reflect.Type) graphql.Output {
+	switch {
+	case t == dateType:
+		return dateScalar
+	case t == timeType:
+		return graphql.NewNonNull(graphql.DateTime)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return graphql.NewNonNull(graphql.String)
+	case reflect.Int:
+		return graphql.NewNonNull(graphql.Int)
+	case reflect.Float64:
+		return graphql.NewNonNull(graphql.Float)
+	case reflect.Bool:
+		return graphql.NewNonNull(graphql.Boolean)
+	case reflect.Slice:
+		return graphql.NewNonNull(graphql.NewList(o.outputType(t.Elem())))
+	case reflect.Ptr:
+		if obj, ok := o.types[t.Elem()]; ok {
+			return graphql.NewNonNull(obj)
+		}
+	}
+
+	panic(fmt.Sprintf("graph: no GraphQL type for %s", t))
+}
+
+// resolveField resolves the struct field at index of the source, which must
+// be a pointer to a struct.
+func resolveField(index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v := reflect.ValueOf(p.Source)
+		if v.Kind() != reflect.Ptr || v.IsNil() {
+			return nil, nil
+		}
+		return v.Elem().FieldByIndex(index).Interface(), nil
+	}
+}
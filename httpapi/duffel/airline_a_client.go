@@ -18,7 +18,7 @@ type AirlineAClient struct {
 	client  *http.Client
 }
 
-func NewAirlineAClient() *AirlineAClient {
+func NewAirlineAClient(policy httpapi.Policy) *AirlineAClient {
 	url, err := url.Parse("http://interview.duffel.com/airline_a")
 	if err != nil {
 		panic(err)
@@ -26,7 +26,7 @@ func NewAirlineAClient() *AirlineAClient {
 
 	return &AirlineAClient{
 		BaseURL: url,
-		client:  http.DefaultClient,
+		client:  httpapi.NewClient("airline_a", policy),
 	}
 }
 
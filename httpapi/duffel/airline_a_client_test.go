@@ -78,7 +78,7 @@ func setupAirlineA(t *testing.T) (*http.ServeMux, domain.FlightsService) {
 	serverURL, err := url.Parse(server.URL)
 	assert.NoError(t, err)
 
-	client := duffel.NewAirlineAClient()
+	client := duffel.NewAirlineAClient(httpapi.Policy{})
 	client.BaseURL = serverURL
 
 	return handler, client
@@ -18,7 +18,7 @@ type AirlineBClient struct {
 	client  *http.Client
 }
 
-func NewAirlineBClient() *AirlineBClient {
+func NewAirlineBClient(policy httpapi.Policy) *AirlineBClient {
 	url, err := url.Parse("http://interview.duffel.com/airline_b")
 	if err != nil {
 		panic(err)
@@ -26,7 +26,7 @@ func NewAirlineBClient() *AirlineBClient {
 
 	return &AirlineBClient{
 		BaseURL: url,
-		client:  http.DefaultClient,
+		client:  httpapi.NewClient("airline_b", policy),
 	}
 }
 
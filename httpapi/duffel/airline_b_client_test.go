@@ -78,7 +78,7 @@ func setupAirlineB(t *testing.T) (*http.ServeMux, domain.FlightsService) {
 	serverURL, err := url.Parse(server.URL)
 	assert.NoError(t, err)
 
-	client := duffel.NewAirlineBClient()
+	client := duffel.NewAirlineBClient(httpapi.Policy{})
 	client.BaseURL = serverURL
 
 	return handler, client
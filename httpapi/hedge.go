@@ -0,0 +1,266 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	hedgesFired = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "httpapi_hedges_fired_total",
+		Help: "Duplicate requests sent because the original was slower than the hedge threshold.",
+	}, []string{"base_url"})
+
+	hedgesWon = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "httpapi_hedges_won_total",
+		Help: "Hedged requests whose response arrived before the original's.",
+	}, []string{"base_url"})
+
+	hedgesThrottled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "httpapi_hedges_throttled_total",
+		Help: "Hedges skipped because the hedge budget was exhausted.",
+	}, []string{"base_url"})
+)
+
+// HedgePolicy configures hedged requests. Hedging sends duplicates of
+// whatever request it is given, so it should only be enabled for downstreams
+// where repeating a request is safe, even if the method is not idempotent.
+type HedgePolicy struct {
+	// Percentile of recent latency, in (0, 1), after which a duplicate
+	// request is sent.
+	Percentile float64
+	// MaxRate caps hedged requests as a fraction of all requests.
+	MaxRate float64
+	// MinSamples is the number of latencies observed before hedging starts.
+	MinSamples int
+	// Window is the number of recent latencies the percentile is taken over.
+	Window int
+}
+
+func DefaultHedgePolicy() HedgePolicy {
+	return HedgePolicy{
+		Percentile: 0.95,
+		MaxRate:    0.1,
+		MinSamples: 20,
+		Window:     200,
+	}
+}
+
+// HedgingTransport is an http.RoundTripper that races a duplicate request
+// against any request outliving the configured latency percentile of its
+// downstream, returning whichever succeeds first.
+type HedgingTransport struct {
+	next   http.RoundTripper
+	policy HedgePolicy
+
+	mu    sync.Mutex
+	stats map[string]*latencyStats
+}
+
+func NewHedgingTransport(next http.RoundTripper, policy HedgePolicy) *HedgingTransport {
+	return &HedgingTransport{
+		next:   next,
+		policy: policy,
+		stats:  make(map[string]*latencyStats),
+	}
+}
+
+type hedgeResult struct {
+	rsp     *http.Response
+	err     error
+	attempt int
+	latency time.Duration
+	cancel  context.CancelFunc
+}
+
+func (r hedgeResult) ok() bool {
+	return r.err == nil && r.rsp.StatusCode < 500
+}
+
+func (t *HedgingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := baseURLFromContext(req)
+	stats := t.latencyStats(key)
+
+	delay, ok := stats.threshold(t.policy)
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		start := time.Now()
+		rsp, err := t.next.RoundTrip(req)
+		if err == nil {
+			stats.observe(time.Since(start))
+		}
+		return rsp, err
+	}
+
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+
+	launch := func(req *http.Request) {
+		ctx, cancel := context.WithCancel(req.Context())
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+
+		go func() {
+			start := time.Now()
+			rsp, err := t.next.RoundTrip(req.WithContext(ctx))
+			results <- hedgeResult{rsp: rsp, err: err, attempt: attempt, latency: time.Since(start), cancel: cancel}
+		}()
+	}
+
+	launch(req)
+	inflight := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if !stats.spend() {
+				hedgesThrottled.WithLabelValues(key).Inc()
+				continue
+			}
+
+			hedge := req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					continue
+				}
+				hedge.Body = body
+			}
+
+			hedgesFired.WithLabelValues(key).Inc()
+			launch(hedge)
+			inflight++
+
+		case res := <-results:
+			inflight--
+			if !res.ok() && inflight > 0 {
+				if res.rsp != nil {
+					res.rsp.Body.Close()
+				}
+				res.cancel()
+				continue
+			}
+
+			for i, cancel := range cancels {
+				if i != res.attempt {
+					cancel()
+				}
+			}
+			go discard(results, inflight)
+
+			if res.err != nil {
+				res.cancel()
+				return nil, res.err
+			}
+
+			stats.observe(res.latency)
+			if res.attempt > 0 {
+				hedgesWon.WithLabelValues(key).Inc()
+			}
+
+			res.rsp.Body = &cancelOnClose{ReadCloser: res.rsp.Body, cancel: res.cancel}
+			return res.rsp, nil
+		}
+	}
+}
+
+func (t *HedgingTransport) latencyStats(key string) *latencyStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, ok := t.stats[key]
+	if !ok {
+		window := t.policy.Window
+		if window <= 0 {
+			window = DefaultHedgePolicy().Window
+		}
+		stats = &latencyStats{window: window}
+		t.stats[key] = stats
+	}
+	return stats
+}
+
+// discard closes the responses of requests that lost the race.
+func discard(results <-chan hedgeResult, n int) {
+	for i := 0; i < n; i++ {
+		res := <-results
+		if res.rsp != nil {
+			res.rsp.Body.Close()
+		}
+		res.cancel()
+	}
+}
+
+// latencyStats keeps a ring buffer of recent latencies for a downstream,
+// along with request and hedge counts used to enforce the hedge budget.
+type latencyStats struct {
+	window int
+
+	mu       sync.Mutex
+	samples  []time.Duration
+	next     int
+	requests int
+	hedges   int
+	maxRate  float64
+}
+
+// threshold returns how long to wait before hedging and accrues hedge budget
+// for the request being made.
+func (s *latencyStats) threshold(policy HedgePolicy) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxRate = policy.MaxRate
+	s.requests++
+	if s.window > 0 && s.requests > s.window {
+		s.requests /= 2
+		s.hedges /= 2
+	}
+
+	if len(s.samples) == 0 || len(s.samples) < policy.MinSamples {
+		return 0, false
+	}
+
+	sorted := make([]time.Duration, len(s.samples))
+	copy(sorted, s.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	idx := int(policy.Percentile * float64(len(sorted)))
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+
+	return sorted[idx], true
+}
+
+func (s *latencyStats) spend() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if float64(s.hedges+1) > s.maxRate*float64(s.requests) {
+		return false
+	}
+	s.hedges++
+	return true
+}
+
+func (s *latencyStats) observe(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.samples) < s.window {
+		s.samples = append(s.samples, latency)
+		return
+	}
+
+	s.samples[s.next] = latency
+	s.next = (s.next + 1) % s.window
+}
@@ -0,0 +1,76 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/httpapi"
+)
+
+func TestHedgingTransport(t *testing.T) {
+	tt := []struct {
+		Name             string
+		MaxRate          float64
+		ExpectedAttempts int32
+		ExpectHedgeWin   bool
+	}{
+		{
+			Name:             "Hedges slow request and returns first response",
+			MaxRate:          1,
+			ExpectedAttempts: 7,
+			ExpectHedgeWin:   true,
+		},
+		{
+			Name:             "Does not hedge when budget is exhausted",
+			MaxRate:          0,
+			ExpectedAttempts: 6,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			var attempts int32
+			baseURL := setupDownstream(t, func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) == 6 {
+					select {
+					case <-r.Context().Done():
+					case <-time.After(500 * time.Millisecond):
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			client := httpapi.NewClient("test", httpapi.Policy{
+				Hedge: &httpapi.HedgePolicy{
+					Percentile: 0.9,
+					MaxRate:    tc.MaxRate,
+					MinSamples: 5,
+					Window:     10,
+				},
+			})
+
+			for i := 0; i < 5; i++ {
+				req, err := httpapi.NewRequest(context.Background(), baseURL, http.MethodPost, "/", nil)
+				assert.NoError(t, err)
+
+				_, err = httpapi.Do(client, req, nil)
+				assert.NoError(t, err)
+			}
+
+			req, err := httpapi.NewRequest(context.Background(), baseURL, http.MethodPost, "/", nil)
+			assert.NoError(t, err)
+
+			start := time.Now()
+			rsp, err := httpapi.Do(client, req, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rsp.StatusCode)
+			assert.Equal(t, tc.ExpectHedgeWin, time.Since(start) < 500*time.Millisecond)
+			assert.Equal(t, tc.ExpectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
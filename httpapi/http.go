@@ -10,6 +10,12 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -17,6 +23,20 @@ var (
 	ErrStatusCodeUnknown     = errors.New("unexpected response code")
 )
 
+// NewClient returns an http.Client for the named downstream that records
+// metrics for every attempt and applies the given Policy.
+func NewClient(downstream string, policy Policy) *http.Client {
+	var transport http.RoundTripper = NewInstrumentedTransport(http.DefaultTransport, downstream)
+	transport = NewTransport(transport, policy)
+	if policy.Hedge != nil {
+		transport = NewHedgingTransport(transport, *policy.Hedge)
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
 func NewRequest(ctx context.Context, baseURL *url.URL, method, endpoint string, body interface{}) (*http.Request, error) {
 	requestURL, err := baseURL.Parse(strings.Trim(endpoint, "/"))
 	if err != nil {
@@ -32,6 +52,7 @@ func NewRequest(ctx context.Context, baseURL *url.URL, method, endpoint string,
 		}
 	}
 
+	ctx = context.WithValue(ctx, baseURLKey{}, baseURL.String())
 	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), buf)
 	if err != nil {
 		return nil, err
@@ -43,12 +64,54 @@ func NewRequest(ctx context.Context, baseURL *url.URL, method, endpoint string,
 	return req, nil
 }
 
+type baseURLKey struct{}
+
+// baseURLFromContext returns the base URL the request was built from by
+// NewRequest, falling back to the request's scheme and host.
+func baseURLFromContext(req *http.Request) string {
+	if baseURL, ok := req.Context().Value(baseURLKey{}).(string); ok {
+		return baseURL
+	}
+	return req.URL.Scheme + "://" + req.URL.Host
+}
+
 type Response struct {
 	*http.Response
 	HTTPErrorBody string
 }
 
+var tracer = otel.Tracer("github.com/jace-ys/simple-api/httpapi")
+
 func Do(client *http.Client, req *http.Request, v interface{}) (*Response, error) {
+	ctx, span := tracer.Start(req.Context(), req.Method+" "+endpointFromContext(req),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(req.URL.String()),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	rsp, err := do(client, req, v)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(rsp.StatusCode))
+	if rsp.StatusCode >= 500 {
+		span.SetStatus(codes.Error, http.StatusText(rsp.StatusCode))
+	}
+
+	return rsp, nil
+}
+
+func do(client *http.Client, req *http.Request, v interface{}) (*Response, error) {
 	rsp, err := client.Do(req)
 	if err != nil {
 		return nil, err
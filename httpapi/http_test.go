@@ -0,0 +1,46 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/jace-ys/simple-api/httpapi"
+)
+
+func TestDoTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var traceparent string
+	baseURL := setupDownstream(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := httpapi.NewClient("test", httpapi.Policy{})
+
+	ctx := httpapi.WithEndpoint(context.Background(), "/movies/{id}")
+	req, err := httpapi.NewRequest(ctx, baseURL, http.MethodGet, "/movies/1", nil)
+	assert.NoError(t, err)
+
+	_, err = httpapi.Do(client, req, nil)
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "GET /movies/{id}", span.Name())
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+	assert.Contains(t, span.Attributes(), attribute.String("supplier", "test"))
+	assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+}
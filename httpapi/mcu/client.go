@@ -2,23 +2,30 @@ package mcu
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
+	"sync"
 
 	"github.com/jace-ys/simple-api/domain"
 	"github.com/jace-ys/simple-api/httpapi"
 )
 
-var _ domain.MoviesService = (*Client)(nil)
+var (
+	_ domain.MoviesService      = (*Client)(nil)
+	_ domain.DataQualityService = (*Client)(nil)
+)
 
 type Client struct {
 	BaseURL *url.URL
 	client  *http.Client
+
+	mu     sync.Mutex
+	report *domain.DataQualityReport
 }
 
-func NewClient() *Client {
+func NewClient(policy httpapi.Policy) *Client {
 	url, err := url.Parse("https://mcuapi.herokuapp.com/api/v1/")
 	if err != nil {
 		panic(err)
@@ -26,7 +33,7 @@ func NewClient() *Client {
 
 	return &Client{
 		BaseURL: url,
-		client:  http.DefaultClient,
+		client:  httpapi.NewClient("mcu", policy),
 	}
 }
 
@@ -47,27 +54,25 @@ type movie struct {
 	ImdbID           string `json:"imdb_id"`
 }
 
-func (m *movie) toDomain() (*domain.Movie, error) {
-	bo, err := strconv.Atoi(m.BoxOffice)
+func (c *Client) GetMovies(ctx context.Context) (domain.Movies, error) {
+	records, err := c.GetMovieRecords(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &domain.Movie{
-		ID:               m.ID,
-		Title:            m.Title,
-		ReleaseDate:      m.ReleaseDate,
-		BoxOffice:        bo,
-		DurationMinutes:  m.Duration,
-		Overview:         m.Overview,
-		Phase:            m.Phase,
-		Saga:             m.Saga,
-		Chronology:       m.Chronology,
-		PostCreditScenes: m.PostCreditScenes,
-	}, nil
+	movies, report := decodeMovies(records)
+	recordDataQuality(report)
+
+	c.mu.Lock()
+	c.report = report
+	c.mu.Unlock()
+
+	return movies, nil
 }
 
-func (c *Client) GetMovies(ctx context.Context) (domain.Movies, error) {
+// GetMovieRecords returns the records of the list movies response as they were
+// received, without decoding them.
+func (c *Client) GetMovieRecords(ctx context.Context) ([]json.RawMessage, error) {
 	endpoint := "/movies"
 	req, err := httpapi.NewRequest(ctx, c.BaseURL, http.MethodGet, endpoint, nil)
 	if err != nil {
@@ -75,7 +80,7 @@ func (c *Client) GetMovies(ctx context.Context) (domain.Movies, error) {
 	}
 
 	var res struct {
-		Data []movie `json:"data"`
+		Data []json.RawMessage `json:"data"`
 	}
 	rsp, err := httpapi.Do(c.client, req, &res)
 	if err != nil {
@@ -91,26 +96,18 @@ func (c *Client) GetMovies(ctx context.Context) (domain.Movies, error) {
 		return nil, fmt.Errorf("%w: %d", httpapi.ErrStatusCodeUnknown, rsp.StatusCode)
 	}
 
-	movies := make(domain.Movies, len(res.Data))
-	for i, m := range res.Data {
-		movie, err := m.toDomain()
-		if err != nil {
-			return nil, err
-		}
-		movies[i] = movie
-	}
-
-	return movies, nil
+	return res.Data, nil
 }
 
 func (c *Client) GetMovie(ctx context.Context, movieID int) (*domain.Movie, error) {
+	ctx = httpapi.WithEndpoint(ctx, "/movies/{id}")
 	endpoint := fmt.Sprintf("/movies/%d", movieID)
 	req, err := httpapi.NewRequest(ctx, c.BaseURL, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var res movie
+	var res json.RawMessage
 	rsp, err := httpapi.Do(c.client, req, &res)
 	if err != nil {
 		return nil, err
@@ -127,10 +124,30 @@ func (c *Client) GetMovie(ctx context.Context, movieID int) (*domain.Movie, erro
 		return nil, fmt.Errorf("%w: %d", httpapi.ErrStatusCodeUnknown, rsp.StatusCode)
 	}
 
-	movie, err := res.toDomain()
-	if err != nil {
-		return nil, err
+	movie, _ := decodeMovie(0, res)
+	if movie == nil {
+		return nil, fmt.Errorf("invalid movie record: %s", res)
 	}
 
 	return movie, nil
 }
+
+// GetDataQuality returns the issues found in the last movie list fetched,
+// fetching it if there hasn't been one yet.
+func (c *Client) GetDataQuality(ctx context.Context) (*domain.DataQualityReport, error) {
+	c.mu.Lock()
+	report := c.report
+	c.mu.Unlock()
+
+	if report != nil {
+		return report, nil
+	}
+
+	if _, err := c.GetMovies(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.report, nil
+}
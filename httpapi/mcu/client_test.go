@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -19,26 +20,34 @@ func TestGetMovies(t *testing.T) {
 	movie1 := &domain.Movie{
 		ID:               1,
 		Title:            "Iron Man",
-		ReleaseDate:      "2008-05-02",
+		ReleaseDate:      domain.MustParseDate("2008-05-02"),
 		BoxOffice:        585171547,
 		DurationMinutes:  126,
 		Overview:         "2008's Iron Man tells the story of Tony Stark, a billionaire industrialist and genius inventor who is kidnapped and forced to build a devastating weapon. Instead, using his intelligence and ingenuity, Tony builds a high-tech suit of armor and escapes captivity. When he uncovers a nefarious plot with global implications, he dons his powerful armor and vows to protect the world as Iron Man.",
+		CoverURL:         "https://raw.githubusercontent.com/AugustoMarcelo/mcuapi/master/covers/iron-man.jpg",
+		TrailerURL:       "https://players.brightcove.net/5359769168001/BJemW31x6g_default/index.html?videoId=5786306590001",
+		DirectedBy:       "Jon Favreau",
 		Phase:            1,
 		Saga:             "Infinity Saga",
 		Chronology:       3,
 		PostCreditScenes: 1,
+		ImdbID:           "tt0371746",
 	}
 	movie2 := &domain.Movie{
 		ID:               2,
 		Title:            "The Incredible Hulk",
-		ReleaseDate:      "2008-06-13",
+		ReleaseDate:      domain.MustParseDate("2008-06-13"),
 		BoxOffice:        265573859,
 		DurationMinutes:  112,
 		Overview:         "In this new beginning, scientist Bruce Banner desperately hunts for a cure to the gamma radiation that poisoned his cells and unleashes the unbridled force of rage within him: The Hulk. Living in the shadows--cut off from a life he knew and the woman he loves, Betty Ross--Banner struggles to avoid the obsessive pursuit of his nemesis, General Thunderbolt Ross and the military machinery that seeks to capture him and brutally exploit his power. As all three grapple with the secrets that led to the Hulk's creation, they are confronted with a monstrous new adversary known as the Abomination, whose destructive strength exceeds even the Hulk's own. One scientist must make an agonizing final choice: accept a peaceful life as Bruce Banner or find heroism in the creature he holds inside--The Incredible Hulk.",
+		CoverURL:         "https://raw.githubusercontent.com/AugustoMarcelo/mcuapi/master/covers/hulk.jpg",
+		TrailerURL:       "https://players.brightcove.net/5359769168001/rkg9u15t7b_default/index.html?videoId=5786823800001",
+		DirectedBy:       "Louis Leterrier",
 		Phase:            1,
 		Saga:             "Infinity Saga",
 		Chronology:       5,
 		PostCreditScenes: 1,
+		ImdbID:           "tt0800080",
 	}
 
 	tt := []struct {
@@ -96,18 +105,92 @@ func TestGetMovies(t *testing.T) {
 	}
 }
 
+func TestGetMoviesUnannouncedReleaseDates(t *testing.T) {
+	handler, client := setupMCU(t)
+
+	handler.HandleFunc("/movies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [
+			{"id": 1, "release_date": "2026-07-31", "box_office": "0"},
+			{"id": 2, "release_date": "TBA", "box_office": "0"},
+			{"id": 3, "release_date": null, "box_office": "0"},
+			{"id": 4, "release_date": "2027", "box_office": "0"}
+		]}`))
+	})
+
+	movies, err := client.GetMovies(context.Background())
+	assert.NoError(t, err)
+
+	releaseDates := make(map[int]domain.Date)
+	for _, movie := range movies {
+		releaseDates[movie.ID] = movie.ReleaseDate
+	}
+	assert.Equal(t, map[int]domain.Date{
+		1: domain.NewDate(2026, time.July, 31),
+		2: {},
+		3: {},
+		4: {},
+	}, releaseDates)
+}
+
+func TestGetMoviesDataQuality(t *testing.T) {
+	handler, client := setupMCU(t)
+
+	handler.HandleFunc("/movies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [
+			{"id": 1, "title": "Iron Man", "release_date": "2008-05-02", "box_office": "585171547", "duration": 126, "saga": "Infinity Saga"},
+			{"id": 2, "title": "Blade", "release_date": "TBA", "box_office": "TBA", "duration": 0, "saga": "Infinity Saga"},
+			{"id": 3, "title": "Untitled", "release_date": "Summer 2027", "box_office": "$1,000", "duration": "two hours", "phase": [4], "saga": "Mutant Saga"},
+			{"title": "No ID"},
+			{"id": 1, "title": "Iron Man (duplicate)"},
+			"garbage"
+		]}`))
+	})
+
+	movies, err := client.GetMovies(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, movies, 3)
+
+	assert.Equal(t, 0, movies[1].BoxOffice)
+	assert.True(t, movies[1].ReleaseDate.IsZero())
+	assert.Equal(t, 1000, movies[2].BoxOffice)
+	assert.True(t, movies[2].ReleaseDate.IsZero())
+	assert.Equal(t, 0, movies[2].DurationMinutes)
+
+	report, err := client.(domain.DataQualityService).GetDataQuality(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 6, report.TotalRecords)
+	assert.Equal(t, 3, report.SkippedRecords)
+	assert.Equal(t, []*domain.DataQualityIssue{
+		{Record: 1, MovieID: 2, Field: "box_office", Problem: domain.DataQualityMissing, Value: "TBA"},
+		{Record: 1, MovieID: 2, Field: "duration", Problem: domain.DataQualityMissing},
+		{Record: 2, MovieID: 3, Field: "duration", Problem: domain.DataQualityUnparsable, Value: "two hours"},
+		{Record: 2, MovieID: 3, Field: "phase", Problem: domain.DataQualityUnparsable, Value: "[4]"},
+		{Record: 2, MovieID: 3, Field: "release_date", Problem: domain.DataQualityUnparsable, Value: "Summer 2027"},
+		{Record: 2, MovieID: 3, Field: "saga", Problem: domain.DataQualityUnknown, Value: "Mutant Saga"},
+		{Record: 3, Field: "id", Problem: domain.DataQualityInvalid, Skipped: true},
+		{Record: 4, MovieID: 1, Field: "id", Problem: domain.DataQualityInvalid, Value: "1", Skipped: true},
+		{Record: 5, Problem: domain.DataQualityInvalid, Skipped: true},
+	}, report.Issues)
+}
+
 func TestGetMovie(t *testing.T) {
 	movie := &domain.Movie{
 		ID:               1,
 		Title:            "Iron Man",
-		ReleaseDate:      "2008-05-02",
+		ReleaseDate:      domain.MustParseDate("2008-05-02"),
 		BoxOffice:        585171547,
 		DurationMinutes:  126,
 		Overview:         "2008's Iron Man tells the story of Tony Stark, a billionaire industrialist and genius inventor who is kidnapped and forced to build a devastating weapon. Instead, using his intelligence and ingenuity, Tony builds a high-tech suit of armor and escapes captivity. When he uncovers a nefarious plot with global implications, he dons his powerful armor and vows to protect the world as Iron Man.",
+		CoverURL:         "https://raw.githubusercontent.com/AugustoMarcelo/mcuapi/master/covers/iron-man.jpg",
+		TrailerURL:       "https://players.brightcove.net/5359769168001/BJemW31x6g_default/index.html?videoId=5786306590001",
+		DirectedBy:       "Jon Favreau",
 		Phase:            1,
 		Saga:             "Infinity Saga",
 		Chronology:       3,
 		PostCreditScenes: 1,
+		ImdbID:           "tt0371746",
 	}
 
 	tt := []struct {
@@ -177,7 +260,7 @@ func setupMCU(t *testing.T) (*http.ServeMux, domain.MoviesService) {
 	serverURL, err := url.Parse(server.URL)
 	assert.NoError(t, err)
 
-	client := mcu.NewClient()
+	client := mcu.NewClient(httpapi.Policy{})
 	client.BaseURL = serverURL
 
 	return handler, client
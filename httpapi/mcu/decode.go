@@ -0,0 +1,233 @@
+package mcu
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+var (
+	dataQualityIssues = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mcu_data_quality_issues",
+		Help: "Data-quality issues in the most recently decoded MCU movie list, by field and problem.",
+	}, []string{"field", "problem"})
+
+	dataQualitySkipped = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "mcu_data_quality_skipped_records",
+		Help: "Records skipped from the most recently decoded MCU movie list.",
+	})
+)
+
+// knownSagas are the sagas movies are expected to belong to.
+var knownSagas = map[string]bool{
+	"Infinity Saga":   true,
+	"Multiverse Saga": true,
+}
+
+// unannouncedBoxOffice are placeholders upstream uses for box office figures
+// of unreleased movies.
+var unannouncedBoxOffice = map[string]bool{
+	"":    true,
+	"tba": true,
+	"n/a": true,
+}
+
+// decodeMovies decodes the records of a list movies response one at a time,
+// defaulting bad fields and skipping unusable records rather than failing the
+// whole response.
+func decodeMovies(records []json.RawMessage) (domain.Movies, *domain.DataQualityReport) {
+	report := &domain.DataQualityReport{
+		CheckedAt:    time.Now(),
+		TotalRecords: len(records),
+		Issues:       []*domain.DataQualityIssue{},
+	}
+
+	movies := make(domain.Movies, 0, len(records))
+	seen := make(map[int]bool, len(records))
+	for i, record := range records {
+		movie, issues := decodeMovie(i, record)
+		if movie != nil && seen[movie.ID] {
+			issues = []*domain.DataQualityIssue{{
+				Record:  i,
+				MovieID: movie.ID,
+				Field:   "id",
+				Problem: domain.DataQualityInvalid,
+				Value:   strconv.Itoa(movie.ID),
+				Skipped: true,
+			}}
+			movie = nil
+		}
+
+		report.Issues = append(report.Issues, issues...)
+		if movie == nil {
+			report.SkippedRecords++
+			continue
+		}
+
+		seen[movie.ID] = true
+		movies = append(movies, movie)
+	}
+
+	return movies, report
+}
+
+// decodeMovie decodes a single record, returning a nil movie if it had to be
+// skipped.
+func decodeMovie(record int, data json.RawMessage) (*domain.Movie, []*domain.DataQualityIssue) {
+	var issues []*domain.DataQualityIssue
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, []*domain.DataQualityIssue{{Record: record, Problem: domain.DataQualityInvalid, Skipped: true}}
+	}
+
+	// Fields of the wrong type are left as zero values and reported with the
+	// value they had, rather than making the record unusable.
+	var m movie
+	unparsable := make(map[string]bool)
+	for _, field := range m.fields() {
+		raw, ok := fields[field.name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, field.value); err != nil {
+			unparsable[field.name] = true
+			issues = append(issues, &domain.DataQualityIssue{
+				Field:   field.name,
+				Problem: domain.DataQualityUnparsable,
+				Value:   rawValue(raw),
+			})
+		}
+	}
+
+	if m.ID <= 0 {
+		return nil, []*domain.DataQualityIssue{{Record: record, Field: "id", Problem: domain.DataQualityInvalid, Skipped: true}}
+	}
+
+	// Fields of the wrong type are also reported as missing by toDomain, so
+	// only the unparsable issue is kept.
+	movie, fieldIssues := m.toDomain()
+	for _, issue := range fieldIssues {
+		if !unparsable[issue.Field] {
+			issues = append(issues, issue)
+		}
+	}
+	for _, issue := range issues {
+		issue.Record = record
+		issue.MovieID = m.ID
+	}
+
+	return movie, issues
+}
+
+type movieField struct {
+	name  string
+	value interface{}
+}
+
+// fields returns the JSON name of each of the movie's fields along with a
+// pointer to decode it into, in the order they're declared.
+func (m *movie) fields() []movieField {
+	return []movieField{
+		{"id", &m.ID},
+		{"title", &m.Title},
+		{"release_date", &m.ReleaseDate},
+		{"box_office", &m.BoxOffice},
+		{"duration", &m.Duration},
+		{"overview", &m.Overview},
+		{"cover_url", &m.CoverURL},
+		{"trailer_url", &m.TrailerURL},
+		{"directed_by", &m.DirectedBy},
+		{"phase", &m.Phase},
+		{"saga", &m.Saga},
+		{"chronology", &m.Chronology},
+		{"post_credit_scenes", &m.PostCreditScenes},
+		{"imdb_id", &m.ImdbID},
+	}
+}
+
+// rawValue returns raw as it's reported in data-quality issues: strings
+// without their quotes, and anything else as it appeared in the record.
+func rawValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// toDomain converts the movie, defaulting any fields that are missing or
+// can't be parsed and reporting them as issues.
+func (m *movie) toDomain() (*domain.Movie, []*domain.DataQualityIssue) {
+	var issues []*domain.DataQualityIssue
+	report := func(field string, problem domain.DataQualityProblem, value string) {
+		issues = append(issues, &domain.DataQualityIssue{Field: field, Problem: problem, Value: value})
+	}
+
+	if m.Title == "" {
+		report("title", domain.DataQualityMissing, "")
+	}
+
+	var boxOffice int
+	if raw := strings.TrimSpace(m.BoxOffice); unannouncedBoxOffice[strings.ToLower(raw)] {
+		report("box_office", domain.DataQualityMissing, m.BoxOffice)
+	} else if bo, err := strconv.Atoi(strings.NewReplacer("$", "", ",", "").Replace(raw)); err != nil || bo < 0 {
+		report("box_office", domain.DataQualityUnparsable, m.BoxOffice)
+	} else {
+		boxOffice = bo
+	}
+
+	// Unannounced release dates parse as unknown dates, so only malformed
+	// ones are reported.
+	releaseDate, err := domain.ParseDate(m.ReleaseDate)
+	if err != nil {
+		report("release_date", domain.DataQualityUnparsable, m.ReleaseDate)
+	}
+
+	if m.Duration <= 0 {
+		report("duration", domain.DataQualityMissing, "")
+	}
+
+	switch {
+	case m.Saga == "":
+		report("saga", domain.DataQualityMissing, "")
+	case !knownSagas[m.Saga]:
+		report("saga", domain.DataQualityUnknown, m.Saga)
+	}
+
+	return &domain.Movie{
+		ID:               m.ID,
+		Title:            m.Title,
+		ReleaseDate:      releaseDate,
+		BoxOffice:        boxOffice,
+		DurationMinutes:  max(m.Duration, 0),
+		Overview:         m.Overview,
+		CoverURL:         m.CoverURL,
+		TrailerURL:       m.TrailerURL,
+		DirectedBy:       m.DirectedBy,
+		Phase:            m.Phase,
+		Saga:             m.Saga,
+		Chronology:       m.Chronology,
+		PostCreditScenes: m.PostCreditScenes,
+		ImdbID:           m.ImdbID,
+	}, issues
+}
+
+// recordDataQuality exports the report as metrics.
+func recordDataQuality(report *domain.DataQualityReport) {
+	dataQualityIssues.Reset()
+	for _, issue := range report.Issues {
+		field := issue.Field
+		if field == "" {
+			field = "record"
+		}
+		dataQualityIssues.WithLabelValues(field, string(issue.Problem)).Inc()
+	}
+	dataQualitySkipped.Set(float64(report.SkippedRecords))
+}
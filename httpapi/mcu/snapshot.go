@@ -0,0 +1,97 @@
+package mcu
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+var (
+	_ domain.MoviesService      = (*Snapshot)(nil)
+	_ domain.DataQualityService = (*Snapshot)(nil)
+)
+
+// Snapshot is a domain.MoviesService that serves movies read from a JSON file
+// in the same shape as the MCU API's list movies response, so the API can run
+// without network access.
+type Snapshot struct {
+	movies domain.Movies
+	byID   map[int]*domain.Movie
+	report *domain.DataQualityReport
+
+	loadedAt time.Time
+}
+
+type snapshotFile struct {
+	Data []json.RawMessage `json:"data"`
+}
+
+func LoadSnapshot(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadSnapshot(f)
+}
+
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var file snapshotFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+
+	movies, report := decodeMovies(file.Data)
+	recordDataQuality(report)
+
+	s := &Snapshot{
+		movies:   movies,
+		byID:     make(map[int]*domain.Movie, len(movies)),
+		report:   report,
+		loadedAt: time.Now(),
+	}
+	for _, movie := range movies {
+		s.byID[movie.ID] = movie
+	}
+
+	return s, nil
+}
+
+// WriteSnapshot writes records, as returned by Client.GetMovieRecords, to w in
+// the format read by ReadSnapshot. Records are written as received so that
+// reading the snapshot decodes them exactly as the live API would.
+func WriteSnapshot(w io.Writer, records []json.RawMessage) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshotFile{Data: records})
+}
+
+func (s *Snapshot) GetMovies(ctx context.Context) (domain.Movies, error) {
+	movies := make(domain.Movies, len(s.movies))
+	copy(movies, s.movies)
+	return movies, nil
+}
+
+func (s *Snapshot) GetMovie(ctx context.Context, movieID int) (*domain.Movie, error) {
+	movie, ok := s.byID[movieID]
+	if !ok {
+		return nil, domain.ErrMovieNotFound
+	}
+	return movie, nil
+}
+
+// LoadedAt returns when the snapshot was read. Snapshots never change, so
+// anything derived from its movies can be kept for as long as it's served.
+func (s *Snapshot) LoadedAt() time.Time {
+	return s.loadedAt
+}
+
+// GetDataQuality returns the issues found when the snapshot was read.
+func (s *Snapshot) GetDataQuality(ctx context.Context) (*domain.DataQualityReport, error) {
+	return s.report, nil
+}
@@ -0,0 +1,66 @@
+package mcu_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/httpapi/mcu"
+)
+
+func TestSnapshot(t *testing.T) {
+	snapshot, err := mcu.LoadSnapshot("fixtures/list-movies.json")
+	assert.NoError(t, err)
+	assert.False(t, snapshot.LoadedAt().IsZero())
+
+	movies, err := snapshot.GetMovies(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, movies, 2)
+
+	movie, err := snapshot.GetMovie(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Iron Man", movie.Title)
+	assert.Equal(t, "Jon Favreau", movie.DirectedBy)
+	assert.Equal(t, 585171547, movie.BoxOffice)
+
+	_, err = snapshot.GetMovie(context.Background(), 3)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+}
+
+func TestWriteSnapshot(t *testing.T) {
+	records := []json.RawMessage{
+		json.RawMessage(`{"id":1,"title":"Iron Man","box_office":"585171547","release_date":"2008-05-02","duration":126,"saga":"Infinity Saga"}`),
+		json.RawMessage(`{"id":2,"title":"Blade","box_office":"TBA","release_date":"","saga":"Multiverse Saga"}`),
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, mcu.WriteSnapshot(&buf, records))
+
+	var written struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &written))
+	if assert.Len(t, written.Data, len(records)) {
+		for i, record := range records {
+			assert.JSONEq(t, string(record), string(written.Data[i]))
+		}
+	}
+
+	snapshot, err := mcu.ReadSnapshot(&buf)
+	assert.NoError(t, err)
+
+	report, err := snapshot.GetDataQuality(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, report.Issues, &domain.DataQualityIssue{
+		Record:  1,
+		MovieID: 2,
+		Field:   "box_office",
+		Problem: domain.DataQualityMissing,
+		Value:   "TBA",
+	})
+}
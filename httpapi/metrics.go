@@ -0,0 +1,99 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	downstreamRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "httpapi_downstream_requests_total",
+		Help: "Requests sent to downstream APIs, by status class.",
+	}, []string{"downstream", "endpoint", "method", "status_class"})
+
+	downstreamDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "httpapi_downstream_request_duration_seconds",
+		Help:    "Latency of requests sent to downstream APIs.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"downstream", "endpoint", "method"})
+
+	downstreamErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "httpapi_downstream_errors_total",
+		Help: "Requests to downstream APIs that failed without a response, by error kind.",
+	}, []string{"downstream", "endpoint", "method", "kind"})
+)
+
+type endpointKey struct{}
+
+// WithEndpoint labels requests built from ctx with an endpoint template, such
+// as "/movies/{id}", so that metrics aren't split by path parameters.
+func WithEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+func endpointFromContext(req *http.Request) string {
+	if endpoint, ok := req.Context().Value(endpointKey{}).(string); ok {
+		return endpoint
+	}
+	return req.URL.Path
+}
+
+// InstrumentedTransport is an http.RoundTripper that records request counts,
+// latencies, status classes and error kinds for a named downstream.
+type InstrumentedTransport struct {
+	next       http.RoundTripper
+	downstream string
+}
+
+func NewInstrumentedTransport(next http.RoundTripper, downstream string) *InstrumentedTransport {
+	return &InstrumentedTransport{
+		next:       next,
+		downstream: downstream,
+	}
+}
+
+func (t *InstrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointFromContext(req)
+	trace.SpanFromContext(req.Context()).SetAttributes(attribute.String("supplier", t.downstream))
+
+	start := time.Now()
+	rsp, err := t.next.RoundTrip(req)
+	downstreamDuration.WithLabelValues(t.downstream, endpoint, req.Method).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		downstreamErrors.WithLabelValues(t.downstream, endpoint, req.Method, errorKind(err)).Inc()
+		return nil, err
+	}
+
+	downstreamRequests.WithLabelValues(t.downstream, endpoint, req.Method, statusClass(rsp.StatusCode)).Inc()
+	return rsp, nil
+}
+
+func statusClass(code int) string {
+	return strconv.Itoa(code/100) + "xx"
+}
+
+func errorKind(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, new(*net.OpError)):
+		return "connection"
+	default:
+		return "other"
+	}
+}
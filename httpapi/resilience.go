@@ -0,0 +1,283 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var ErrCircuitOpen = fmt.Errorf("%w: circuit breaker open", ErrDownstreamUnavailable)
+
+var (
+	breakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "httpapi_circuit_breaker_state",
+		Help: "Circuit breaker state per downstream base URL (0 = closed, 1 = half-open, 2 = open).",
+	}, []string{"base_url"})
+
+	breakerRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "httpapi_circuit_breaker_rejections_total",
+		Help: "Requests failed fast because the circuit breaker was open.",
+	}, []string{"base_url"})
+
+	retries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "httpapi_retries_total",
+		Help: "Retried attempts against a downstream base URL.",
+	}, []string{"base_url"})
+)
+
+// Policy configures how calls to a single downstream are bounded, retried and
+// short-circuited.
+type Policy struct {
+	// Timeout bounds each attempt. Zero disables the deadline.
+	Timeout time.Duration
+	// MaxRetries is the number of extra attempts made for idempotent requests
+	// that fail with a transport error or a 5xx response.
+	MaxRetries int
+	// BackoffBase and BackoffMax bound the jittered exponential backoff
+	// between attempts.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// FailureThreshold is the number of consecutive failed calls that opens the
+	// circuit breaker. Zero disables the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before a probe is let
+	// through.
+	OpenTimeout time.Duration
+	// Hedge enables hedged requests when set.
+	Hedge *HedgePolicy
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		Timeout:          10 * time.Second,
+		MaxRetries:       2,
+		BackoffBase:      100 * time.Millisecond,
+		BackoffMax:       2 * time.Second,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+// Transport is an http.RoundTripper that applies a Policy to every request,
+// keeping one circuit breaker per downstream base URL.
+type Transport struct {
+	next   http.RoundTripper
+	policy Policy
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+func NewTransport(next http.RoundTripper, policy Policy) *Transport {
+	return &Transport{
+		next:     next,
+		policy:   policy,
+		breakers: make(map[string]*breaker),
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := baseURLFromContext(req)
+	cb := t.breaker(key)
+
+	if !cb.allow() {
+		breakerRejections.WithLabelValues(key).Inc()
+		return nil, ErrCircuitOpen
+	}
+
+	attempts := 1
+	if isIdempotent(req.Method) && (req.Body == nil || req.GetBody != nil) {
+		attempts += t.policy.MaxRetries
+	}
+
+	for attempt := 1; ; attempt++ {
+		rsp, err := t.attempt(req)
+
+		failed := err != nil || rsp.StatusCode >= 500
+		if !failed || attempt >= attempts || req.Context().Err() != nil {
+			cb.record(!failed, req.Context().Err() != nil)
+			return rsp, err
+		}
+
+		if rsp != nil {
+			io.Copy(io.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
+
+		select {
+		case <-time.After(t.backoff(attempt)):
+		case <-req.Context().Done():
+			cb.record(false, true)
+			return nil, req.Context().Err()
+		}
+
+		retries.WithLabelValues(key).Inc()
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cb.record(false, false)
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func (t *Transport) attempt(req *http.Request) (*http.Response, error) {
+	if t.policy.Timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.policy.Timeout)
+	rsp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	rsp.Body = &cancelOnClose{ReadCloser: rsp.Body, cancel: cancel}
+	return rsp, nil
+}
+
+// backoff returns a full-jitter delay for the given attempt, drawn uniformly
+// from [0, min(BackoffMax, BackoffBase*2^(attempt-1))).
+func (t *Transport) backoff(attempt int) time.Duration {
+	if t.policy.BackoffBase <= 0 {
+		return 0
+	}
+
+	ceiling := t.policy.BackoffBase << (attempt - 1)
+	if t.policy.BackoffMax > 0 && (ceiling > t.policy.BackoffMax || ceiling <= 0) {
+		ceiling = t.policy.BackoffMax
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+func (t *Transport) breaker(key string) *breaker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cb, ok := t.breakers[key]
+	if !ok {
+		cb = newBreaker(key, t.policy.FailureThreshold, t.policy.OpenTimeout)
+		t.breakers[key] = cb
+	}
+	return cb
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+type breakerStatus int
+
+const (
+	breakerClosed breakerStatus = iota
+	breakerHalfOpen
+	breakerOpen
+)
+
+type breaker struct {
+	key         string
+	threshold   int
+	openTimeout time.Duration
+
+	mu       sync.Mutex
+	status   breakerStatus
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(key string, threshold int, openTimeout time.Duration) *breaker {
+	breakerState.WithLabelValues(key).Set(float64(breakerClosed))
+	return &breaker{
+		key:         key,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+	}
+}
+
+// allow reports whether a call may proceed. Once the open timeout elapses the
+// breaker moves to half-open and admits a single probe at a time.
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.status {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.setStatus(breakerHalfOpen)
+		fallthrough
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+
+	return true
+}
+
+// record feeds the outcome of a call back into the breaker. Calls abandoned by
+// the caller say nothing about the downstream's health and are ignored.
+func (b *breaker) record(success, abandoned bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	switch {
+	case abandoned:
+		return
+	case success:
+		b.failures = 0
+		b.setStatus(breakerClosed)
+	default:
+		b.failures++
+		if b.status == breakerHalfOpen || b.failures >= b.threshold {
+			b.openedAt = time.Now()
+			b.setStatus(breakerOpen)
+		}
+	}
+}
+
+func (b *breaker) setStatus(status breakerStatus) {
+	b.status = status
+	breakerState.WithLabelValues(b.key).Set(float64(status))
+}
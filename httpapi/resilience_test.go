@@ -0,0 +1,185 @@
+package httpapi_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/httpapi"
+)
+
+func TestTransportRetries(t *testing.T) {
+	tt := []struct {
+		Name             string
+		Method           string
+		FailuresBefore   int32
+		ExpectedStatus   int
+		ExpectedAttempts int32
+	}{
+		{
+			Name:             "Retries idempotent request until success",
+			Method:           http.MethodGet,
+			FailuresBefore:   2,
+			ExpectedStatus:   http.StatusOK,
+			ExpectedAttempts: 3,
+		},
+		{
+			Name:             "Gives up after max retries",
+			Method:           http.MethodGet,
+			FailuresBefore:   5,
+			ExpectedStatus:   http.StatusServiceUnavailable,
+			ExpectedAttempts: 3,
+		},
+		{
+			Name:             "Does not retry non-idempotent request",
+			Method:           http.MethodPost,
+			FailuresBefore:   1,
+			ExpectedStatus:   http.StatusServiceUnavailable,
+			ExpectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			var attempts int32
+			baseURL := setupDownstream(t, func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) <= tc.FailuresBefore {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			client := httpapi.NewClient("test", httpapi.Policy{
+				MaxRetries:  2,
+				BackoffBase: time.Millisecond,
+				BackoffMax:  5 * time.Millisecond,
+			})
+
+			req, err := httpapi.NewRequest(context.Background(), baseURL, tc.Method, "/", map[string]string{"hello": "world"})
+			assert.NoError(t, err)
+
+			rsp, err := httpapi.Do(client, req, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedStatus, rsp.StatusCode)
+			assert.Equal(t, tc.ExpectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestTransportTimeout(t *testing.T) {
+	baseURL := setupDownstream(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	client := httpapi.NewClient("test", httpapi.Policy{
+		Timeout: 10 * time.Millisecond,
+	})
+
+	req, err := httpapi.NewRequest(context.Background(), baseURL, http.MethodGet, "/", nil)
+	assert.NoError(t, err)
+
+	_, err = httpapi.Do(client, req, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTransportCircuitBreaker(t *testing.T) {
+	var attempts int32
+	baseURL := setupDownstream(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	client := httpapi.NewClient("test", httpapi.Policy{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+	})
+
+	for i := 0; i < 2; i++ {
+		req, err := httpapi.NewRequest(context.Background(), baseURL, http.MethodGet, "/", nil)
+		assert.NoError(t, err)
+
+		rsp, err := httpapi.Do(client, req, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rsp.StatusCode)
+	}
+
+	req, err := httpapi.NewRequest(context.Background(), baseURL, http.MethodGet, "/", nil)
+	assert.NoError(t, err)
+
+	_, err = httpapi.Do(client, req, nil)
+	assert.ErrorIs(t, err, httpapi.ErrCircuitOpen)
+	assert.ErrorIs(t, err, httpapi.ErrDownstreamUnavailable)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestTransportCircuitBreakerReleasesProbe(t *testing.T) {
+	var attempts int32
+	baseURL := setupDownstream(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	transport := httpapi.NewTransport(http.DefaultTransport, httpapi.Policy{
+		MaxRetries:       1,
+		BackoffBase:      time.Millisecond,
+		BackoffMax:       time.Millisecond,
+		FailureThreshold: 1,
+		OpenTimeout:      10 * time.Millisecond,
+	})
+
+	req, err := http.NewRequest(http.MethodGet, baseURL.String(), nil)
+	assert.NoError(t, err)
+
+	rsp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rsp.StatusCode)
+	rsp.Body.Close()
+
+	// The probe let through once the breaker is half-open fails, and its body
+	// can't be replayed for a retry.
+	time.Sleep(20 * time.Millisecond)
+	req, err = http.NewRequest(http.MethodGet, baseURL.String(), strings.NewReader("{}"))
+	assert.NoError(t, err)
+	req.GetBody = func() (io.ReadCloser, error) {
+		return nil, errors.New("body unavailable")
+	}
+
+	_, err = transport.RoundTrip(req)
+	assert.EqualError(t, err, "body unavailable")
+
+	time.Sleep(20 * time.Millisecond)
+	req, err = http.NewRequest(http.MethodGet, baseURL.String(), nil)
+	assert.NoError(t, err)
+
+	rsp, err = transport.RoundTrip(req)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, rsp.StatusCode)
+		rsp.Body.Close()
+	}
+}
+
+func setupDownstream(t *testing.T, handler http.HandlerFunc) *url.URL {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	return serverURL
+}
@@ -0,0 +1,78 @@
+package textmatch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/internal/textmatch"
+)
+
+func TestFold(t *testing.T) {
+	tt := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{
+			Name:     "Lowercases",
+			Input:    "Infinity Saga",
+			Expected: "infinity saga",
+		},
+		{
+			Name:     "Strips diacritics",
+			Input:    "Chloé Zhao",
+			Expected: "chloe zhao",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, textmatch.Fold(tc.Input))
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tt := []struct {
+		Name     string
+		A, B     string
+		Limit    int
+		Expected int
+	}{
+		{
+			Name:     "Returns zero for equal strings",
+			A:        "saga",
+			B:        "saga",
+			Limit:    2,
+			Expected: 0,
+		},
+		{
+			Name:     "Counts insertions, deletions and substitutions",
+			A:        "infnity",
+			B:        "infinite",
+			Limit:    3,
+			Expected: 2,
+		},
+		{
+			Name:     "Counts a transposition as one edit",
+			A:        "soldjre",
+			B:        "soldjer",
+			Limit:    2,
+			Expected: 1,
+		},
+		{
+			Name:     "Gives up once the limit is exceeded",
+			A:        "multiverse",
+			B:        "infinity",
+			Limit:    2,
+			Expected: 3,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, textmatch.EditDistance(tc.A, tc.B, tc.Limit))
+		})
+	}
+}
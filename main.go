@@ -4,73 +4,359 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
-	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/jace-ys/simple-api/catalog"
+	"github.com/jace-ys/simple-api/covers"
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/graph"
+	"github.com/jace-ys/simple-api/httpapi"
 	"github.com/jace-ys/simple-api/httpapi/duffel"
 	"github.com/jace-ys/simple-api/httpapi/mcu"
+	"github.com/jace-ys/simple-api/logging"
+	"github.com/jace-ys/simple-api/rpc"
 	"github.com/jace-ys/simple-api/server"
+	"github.com/jace-ys/simple-api/store"
+	"github.com/jace-ys/simple-api/tracing"
 )
 
 var (
-	port = flag.Int("port", 8000, "Port binding for the HTTP server.")
+	port     = flag.Int("port", 8000, "Port binding for the HTTP server.")
+	grpcPort = flag.Int("grpc-port", 9000, "Port binding for the gRPC server.")
+	logLevel = flag.String("log-level", "info", "Minimum log level: debug, info, warn or error.")
+
+	mcuTimeout         = flag.Duration("mcu-timeout", 10*time.Second, "Per-attempt timeout for requests to the MCU API.")
+	airlineATimeout    = flag.Duration("airline-a-timeout", 5*time.Second, "Per-attempt timeout for requests to airline A.")
+	airlineBTimeout    = flag.Duration("airline-b-timeout", 5*time.Second, "Per-attempt timeout for requests to airline B.")
+	mcuBackend         = flag.String("mcu-backend", "live", "Source of MCU data: live for the MCU API, or snapshot for a local snapshot file.")
+	mcuSnapshot        = flag.String("mcu-snapshot", "mcu-snapshot.json", "Snapshot file served when -mcu-backend=snapshot, as written by cmd/mcu-export.")
+	mcuCatalog         = flag.Bool("mcu-catalog", true, "Serve MCU data from an in-memory catalog refreshed in the background.")
+	mcuRefreshInterval = flag.Duration("mcu-refresh-interval", 10*time.Minute, "Interval between MCU catalog refreshes.")
+	mcuRefreshJitter   = flag.Float64("mcu-refresh-jitter", 0.1, "Random jitter applied to the MCU catalog refresh interval, as a fraction of it.")
+	mcuWebhooks        = flag.String("mcu-webhooks", "", "Comma-separated URLs that MCU catalog changesets are POSTed to.")
+	mcuWebhookSecret   = flag.String("mcu-webhook-secret", "", "Secret used to sign MCU catalog webhooks. Signatures are omitted when empty.")
+	mcuWebhookTimeout  = flag.Duration("mcu-webhook-timeout", 5*time.Second, "Per-attempt timeout for MCU catalog webhook deliveries.")
+
+	watchlistStore = flag.String("watchlist-store", "memory", "Storage for user watchlists: memory, or bolt for an embedded database file.")
+	watchlistDB    = flag.String("watchlist-db", "watchlists.db", "Database file used when -watchlist-store=bolt.")
+	reviewStore    = flag.String("review-store", "memory", "Storage for movie reviews: memory, or bolt for an embedded database file.")
+	reviewDB       = flag.String("review-db", "reviews.db", "Database file used when -review-store=bolt.")
+	adminToken     = flag.String("admin-token", "", "Bearer token required by admin endpoints that change data, such as review moderation. They aren't served when empty.")
+	coverCacheDir  = flag.String("cover-cache-dir", "cover-cache", "Directory movie cover images and their resized variants are cached in.")
+	coverTimeout   = flag.Duration("cover-timeout", 10*time.Second, "Per-attempt timeout for fetching movie cover images.")
+
+	maxRetries = flag.Int("downstream-max-retries", 2, "Maximum retries for idempotent downstream requests.")
+
+	airlineHedgePercentile = flag.Float64("airline-hedge-percentile", 0, "Latency percentile after which airline requests are hedged, e.g. 0.95. Zero disables hedging.")
+	airlineHedgeMaxRate    = flag.Float64("airline-hedge-max-rate", 0.1, "Maximum fraction of airline requests that may be hedged.")
+
+	graphqlMaxDepth      = flag.Int("graphql-max-depth", graph.DefaultLimits().MaxDepth, "Maximum nesting depth of GraphQL queries.")
+	graphqlMaxComplexity = flag.Int("graphql-max-complexity", graph.DefaultLimits().MaxComplexity, "Maximum estimated complexity of GraphQL queries.")
+
+	traceExporter    = flag.String("trace-exporter", tracing.ExporterNone, "Trace exporter to use: none, stdout, file or otlp.")
+	traceFile        = flag.String("trace-file", "traces.json", "File spans are written to when using the file trace exporter.")
+	traceEndpoint    = flag.String("trace-endpoint", "", "Collector host:port for the otlp trace exporter.")
+	traceSampleRatio = flag.Float64("trace-sample-ratio", 1, "Fraction of new traces that are sampled.")
 )
 
 func main() {
+	flag.Parse()
+
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid log level: %s\n", err)
+		os.Exit(2)
+	}
+
+	logger := logging.New(os.Stdout, level)
+	slog.SetDefault(logger)
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName: "simple-api",
+		Exporter:    *traceExporter,
+		File:        *traceFile,
+		Endpoint:    *traceEndpoint,
+		SampleRatio: *traceSampleRatio,
+	})
+	if err != nil {
+		logger.Error("tracing setup error", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	watchlists, closeWatchlists, err := watchlistsStore()
+	if err != nil {
+		logger.Error("watchlist store setup error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer closeWatchlists()
+
+	reviews, closeReviews, err := reviewsStore()
+	if err != nil {
+		logger.Error("review store setup error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer closeReviews()
+
+	cache, err := covers.OpenCache(*coverCacheDir)
+	if err != nil {
+		logger.Error("cover cache setup error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	coversService := covers.NewService(cache, policy(*coverTimeout))
+
+	movies, quality, err := moviesService(ctx, logger)
+	if err != nil {
+		logger.Error("movies service setup error", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	airlineA := duffel.NewAirlineAClient(airlinePolicy(*airlineATimeout))
+	airlineB := duffel.NewAirlineBClient(airlinePolicy(*airlineBTimeout))
+
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", *port),
-		Handler: handler(),
+		Handler: handler(logger, movies, quality, airlineA, airlineB, watchlists, reviews, coversService),
 	}
 
+	grpcSrv := rpc.NewServer(logger, movies,
+		rpc.Supplier{Name: "airline_a", Flights: airlineA},
+		rpc.Supplier{Name: "airline_b", Flights: airlineB},
+	)
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *grpcPort))
+	if err != nil {
+		logger.Error("grpc server listen error", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	go func() {
+		logger.Info("grpc server listening", slog.String("addr", lis.Addr().String()))
+		if err := grpcSrv.Serve(lis); err != nil {
+			logger.Error("grpc server failed to serve", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}()
+
+	shutdown := make(chan struct{})
 	go func() {
+		defer close(shutdown)
 		<-ctx.Done()
 		stop()
 
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		defer cancel()
 
-		log.Println("attempting graceful shutdown")
-		if err := srv.Shutdown(ctx); err != nil {
-			log.Fatalf("server shutdown error: %s\n", err)
+		// Both servers drain at once, so neither keeps accepting requests
+		// while the other stops.
+		logger.Info("attempting graceful shutdown")
+		var g errgroup.Group
+		g.Go(func() error {
+			if err := grpcSrv.Shutdown(ctx); err != nil {
+				logger.Error("grpc server shutdown error", slog.Any("error", err))
+				return err
+			}
+			return nil
+		})
+		g.Go(func() error {
+			if err := srv.Shutdown(ctx); err != nil {
+				logger.Error("server shutdown error", slog.Any("error", err))
+				return err
+			}
+			return nil
+		})
+		if err := g.Wait(); err != nil {
+			os.Exit(1)
 		}
 	}()
 
-	log.Printf("server listening on %s\n", srv.Addr)
+	logger.Info("server listening", slog.String("addr", srv.Addr))
 	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-		log.Fatalf("server failed to serve: %s\n", err)
+		logger.Error("server failed to serve", slog.Any("error", err))
+		os.Exit(1)
 	}
+	<-shutdown
+
+	logger.Info("server stopped")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
 
-	log.Println("server stopped")
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("tracing shutdown error", slog.Any("error", err))
+	}
 }
 
-func handler() http.Handler {
+func handler(logger *slog.Logger, movies domain.MoviesService, quality domain.DataQualityService, airlineA, airlineB domain.FlightsService, watchlists domain.WatchlistStore, reviews domain.ReviewStore, covers domain.CoversService) http.Handler {
 	router := mux.NewRouter()
+	router.Use(server.TraceRoutes, server.InstrumentRoutes)
+	server.HandleUnmatched(router, server.TraceRoutes, server.InstrumentRoutes)
 	router.Handle("/metrics", promhttp.Handler())
 
 	v1 := router.PathPrefix("/api/v1/").Subrouter()
 
 	{
 		router := v1.PathPrefix("/mcu").Subrouter()
-		handler := server.NewMCUHandler(mcu.NewClient())
+		handler := server.NewMCUHandler(movies).WithRatings(reviews)
 		handler.RegisterRoutes(router)
+
+		reviewsHandler := server.NewReviewsHandler(movies, reviews)
+		reviewsHandler.RegisterRoutes(router)
+
+		watchlistHandler := server.NewWatchlistHandler(movies, watchlists)
+		watchlistHandler.RegisterRoutes(router)
+
+		recommendationsHandler := server.NewRecommendationsHandler(movies, watchlists)
+		recommendationsHandler.RegisterRoutes(router)
+
+		coversHandler := server.NewCoversHandler(movies, covers)
+		coversHandler.RegisterRoutes(router)
+
+		if feed, ok := movies.(domain.ChangeFeed); ok {
+			changesHandler := server.NewChangesHandler(feed)
+			changesHandler.RegisterRoutes(router)
+		}
+	}
+
+	{
+		router := v1.PathPrefix("/admin").Subrouter()
+		handler := server.NewDataQualityHandler(quality)
+		handler.RegisterRoutes(router)
+
+		if *adminToken != "" {
+			router := router.PathPrefix("/mcu").Subrouter()
+			router.Use(server.RequireAdminToken(*adminToken))
+
+			reviewsHandler := server.NewReviewsHandler(movies, reviews)
+			reviewsHandler.RegisterAdminRoutes(router)
+		}
 	}
 
 	{
 		router := v1.PathPrefix("/duffel").Subrouter()
-		handler := server.NewDuffelFlightsHandler(duffel.NewAirlineAClient(), duffel.NewAirlineBClient())
+		handler := server.NewDuffelFlightsHandler(airlineA, airlineB)
+		handler.RegisterRoutes(router)
+	}
+
+	{
+		limits := graph.Limits{MaxDepth: *graphqlMaxDepth, MaxComplexity: *graphqlMaxComplexity}
+		schema, err := graph.NewSchema(movies, []domain.FlightsService{airlineA, airlineB}, limits)
+		if err != nil {
+			logger.Error("graphql schema setup error", slog.Any("error", err))
+			os.Exit(1)
+		}
+
+		handler := server.NewGraphQLHandler(schema)
+		handler.RegisterRoutes(v1)
+	}
+
+	{
+		doc, err := server.NewOpenAPI(router)
+		if err != nil {
+			logger.Error("openapi document setup error", slog.Any("error", err))
+			os.Exit(1)
+		}
+		v1.Use(server.ValidateRequests(doc))
+
+		handler := server.NewDocsHandler(doc)
 		handler.RegisterRoutes(router)
 	}
 
-	return handlers.LoggingHandler(os.Stdout, router)
+	return server.LogRequests(logger, router)
+}
+
+// moviesService returns the source of MCU data, along with the data-quality
+// report of what was decoded from upstream.
+func moviesService(ctx context.Context, logger *slog.Logger) (domain.MoviesService, domain.DataQualityService, error) {
+	switch *mcuBackend {
+	case "live":
+	case "snapshot":
+		logger.Info("serving MCU data from snapshot", slog.String("path", *mcuSnapshot))
+		snapshot, err := mcu.LoadSnapshot(*mcuSnapshot)
+		return snapshot, snapshot, err
+	default:
+		return nil, nil, fmt.Errorf("unknown MCU backend %q", *mcuBackend)
+	}
+
+	client := mcu.NewClient(policy(*mcuTimeout))
+	if !*mcuCatalog {
+		return client, client, nil
+	}
+
+	cat := catalog.New(client, *mcuRefreshInterval, *mcuRefreshJitter)
+	prometheus.MustRegister(cat)
+
+	if *mcuWebhooks != "" {
+		webhooks := catalog.NewWebhooks(strings.Split(*mcuWebhooks, ","), *mcuWebhookSecret, policy(*mcuWebhookTimeout))
+		cat.OnChange(webhooks.Notify)
+	}
+
+	if err := cat.Refresh(ctx); err != nil {
+		logger.Warn("initial catalog load error", slog.Any("error", err))
+	}
+	go cat.Run(ctx)
+
+	return cat, client, nil
+}
+
+func watchlistsStore() (domain.WatchlistStore, func() error, error) {
+	switch *watchlistStore {
+	case "memory":
+		return store.NewMemoryWatchlists(), func() error { return nil }, nil
+	case "bolt":
+		s, err := store.OpenBoltWatchlists(*watchlistDB)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s, s.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown watchlist store %q", *watchlistStore)
+	}
+}
+
+func reviewsStore() (domain.ReviewStore, func() error, error) {
+	switch *reviewStore {
+	case "memory":
+		return store.NewMemoryReviews(), func() error { return nil }, nil
+	case "bolt":
+		s, err := store.OpenBoltReviews(*reviewDB)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s, s.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown review store %q", *reviewStore)
+	}
+}
+
+func policy(timeout time.Duration) httpapi.Policy {
+	p := httpapi.DefaultPolicy()
+	p.Timeout = timeout
+	p.MaxRetries = *maxRetries
+	return p
+}
+
+func airlinePolicy(timeout time.Duration) httpapi.Policy {
+	p := policy(timeout)
+	if *airlineHedgePercentile > 0 {
+		hedge := httpapi.DefaultHedgePolicy()
+		hedge.Percentile = *airlineHedgePercentile
+		hedge.MaxRate = *airlineHedgeMaxRate
+		p.Hedge = &hedge
+	}
+	return p
 }
@@ -0,0 +1,6 @@
+// Package proto holds the protobuf definitions of the gRPC API. Generated code
+// is checked in, so protoc, protoc-gen-go and protoc-gen-go-grpc are only
+// needed when the definitions change.
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative simpleapi/v1/mcu.proto simpleapi/v1/flights.proto
@@ -0,0 +1,270 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.29.3
+// source: simpleapi/v1/flights.proto
+
+package simpleapiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchFlightsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IATA airport codes.
+	Origin      string `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Formatted as YYYY-MM-DD.
+	DepartureDate string `protobuf:"bytes,3,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFlightsRequest) Reset() {
+	*x = SearchFlightsRequest{}
+	mi := &file_simpleapi_v1_flights_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFlightsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFlightsRequest) ProtoMessage() {}
+
+func (x *SearchFlightsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_flights_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFlightsRequest.ProtoReflect.Descriptor instead.
+func (*SearchFlightsRequest) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_flights_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchFlightsRequest) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+type Flight struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ArrivalTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=arrival_time,json=arrivalTime,proto3" json:"arrival_time,omitempty"`
+	DepartureTime   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
+	DurationMinutes int32                  `protobuf:"varint,3,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	TotalAmount     float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Currency        string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	FlightNumber    string                 `protobuf:"bytes,6,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	Origin          string                 `protobuf:"bytes,7,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination     string                 `protobuf:"bytes,8,opt,name=destination,proto3" json:"destination,omitempty"`
+	// The supplier the flight was found from.
+	Supplier      string `protobuf:"bytes,9,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Flight) Reset() {
+	*x = Flight{}
+	mi := &file_simpleapi_v1_flights_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Flight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Flight) ProtoMessage() {}
+
+func (x *Flight) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_flights_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Flight.ProtoReflect.Descriptor instead.
+func (*Flight) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_flights_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Flight) GetArrivalTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArrivalTime
+	}
+	return nil
+}
+
+func (x *Flight) GetDepartureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureTime
+	}
+	return nil
+}
+
+func (x *Flight) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *Flight) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Flight) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Flight) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *Flight) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Flight) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Flight) GetSupplier() string {
+	if x != nil {
+		return x.Supplier
+	}
+	return ""
+}
+
+var File_simpleapi_v1_flights_proto protoreflect.FileDescriptor
+
+const file_simpleapi_v1_flights_proto_rawDesc = "" +
+	"\n" +
+	"\x1asimpleapi/v1/flights.proto\x12\fsimpleapi.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"w\n" +
+	"\x14SearchFlightsRequest\x12\x16\n" +
+	"\x06origin\x18\x01 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12%\n" +
+	"\x0edeparture_date\x18\x03 \x01(\tR\rdepartureDate\"\xef\x02\n" +
+	"\x06Flight\x12=\n" +
+	"\farrival_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\varrivalTime\x12A\n" +
+	"\x0edeparture_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureTime\x12)\n" +
+	"\x10duration_minutes\x18\x03 \x01(\x05R\x0fdurationMinutes\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x01R\vtotalAmount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12#\n" +
+	"\rflight_number\x18\x06 \x01(\tR\fflightNumber\x12\x16\n" +
+	"\x06origin\x18\a \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\b \x01(\tR\vdestination\x12\x1a\n" +
+	"\bsupplier\x18\t \x01(\tR\bsupplier2]\n" +
+	"\x0eFlightsService\x12K\n" +
+	"\rSearchFlights\x12\".simpleapi.v1.SearchFlightsRequest\x1a\x14.simpleapi.v1.Flight0\x01B>Z<github.com/jace-ys/simple-api/proto/simpleapi/v1;simpleapiv1b\x06proto3"
+
+var (
+	file_simpleapi_v1_flights_proto_rawDescOnce sync.Once
+	file_simpleapi_v1_flights_proto_rawDescData []byte
+)
+
+func file_simpleapi_v1_flights_proto_rawDescGZIP() []byte {
+	file_simpleapi_v1_flights_proto_rawDescOnce.Do(func() {
+		file_simpleapi_v1_flights_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_simpleapi_v1_flights_proto_rawDesc), len(file_simpleapi_v1_flights_proto_rawDesc)))
+	})
+	return file_simpleapi_v1_flights_proto_rawDescData
+}
+
+var file_simpleapi_v1_flights_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_simpleapi_v1_flights_proto_goTypes = []any{
+	(*SearchFlightsRequest)(nil),  // 0: simpleapi.v1.SearchFlightsRequest
+	(*Flight)(nil),                // 1: simpleapi.v1.Flight
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_simpleapi_v1_flights_proto_depIdxs = []int32{
+	2, // 0: simpleapi.v1.Flight.arrival_time:type_name -> google.protobuf.Timestamp
+	2, // 1: simpleapi.v1.Flight.departure_time:type_name -> google.protobuf.Timestamp
+	0, // 2: simpleapi.v1.FlightsService.SearchFlights:input_type -> simpleapi.v1.SearchFlightsRequest
+	1, // 3: simpleapi.v1.FlightsService.SearchFlights:output_type -> simpleapi.v1.Flight
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_simpleapi_v1_flights_proto_init() }
+func file_simpleapi_v1_flights_proto_init() {
+	if File_simpleapi_v1_flights_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_simpleapi_v1_flights_proto_rawDesc), len(file_simpleapi_v1_flights_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_simpleapi_v1_flights_proto_goTypes,
+		DependencyIndexes: file_simpleapi_v1_flights_proto_depIdxs,
+		MessageInfos:      file_simpleapi_v1_flights_proto_msgTypes,
+	}.Build()
+	File_simpleapi_v1_flights_proto = out.File
+	file_simpleapi_v1_flights_proto_goTypes = nil
+	file_simpleapi_v1_flights_proto_depIdxs = nil
+}
@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: simpleapi/v1/flights.proto
+
+package simpleapiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FlightsService_SearchFlights_FullMethodName = "/simpleapi.v1.FlightsService/SearchFlights"
+)
+
+// FlightsServiceClient is the client API for FlightsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FlightsService searches flights across every airline supplier.
+type FlightsServiceClient interface {
+	// SearchFlights streams flights as each supplier responds, so results from
+	// fast suppliers aren't held up by slow ones. It only fails if every
+	// supplier does.
+	SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Flight], error)
+}
+
+type flightsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFlightsServiceClient(cc grpc.ClientConnInterface) FlightsServiceClient {
+	return &flightsServiceClient{cc}
+}
+
+func (c *flightsServiceClient) SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Flight], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FlightsService_ServiceDesc.Streams[0], FlightsService_SearchFlights_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchFlightsRequest, Flight]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FlightsService_SearchFlightsClient = grpc.ServerStreamingClient[Flight]
+
+// FlightsServiceServer is the server API for FlightsService service.
+// All implementations must embed UnimplementedFlightsServiceServer
+// for forward compatibility.
+//
+// FlightsService searches flights across every airline supplier.
+type FlightsServiceServer interface {
+	// SearchFlights streams flights as each supplier responds, so results from
+	// fast suppliers aren't held up by slow ones. It only fails if every
+	// supplier does.
+	SearchFlights(*SearchFlightsRequest, grpc.ServerStreamingServer[Flight]) error
+	mustEmbedUnimplementedFlightsServiceServer()
+}
+
+// UnimplementedFlightsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFlightsServiceServer struct{}
+
+func (UnimplementedFlightsServiceServer) SearchFlights(*SearchFlightsRequest, grpc.ServerStreamingServer[Flight]) error {
+	return status.Errorf(codes.Unimplemented, "method SearchFlights not implemented")
+}
+func (UnimplementedFlightsServiceServer) mustEmbedUnimplementedFlightsServiceServer() {}
+func (UnimplementedFlightsServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeFlightsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FlightsServiceServer will
+// result in compilation errors.
+type UnsafeFlightsServiceServer interface {
+	mustEmbedUnimplementedFlightsServiceServer()
+}
+
+func RegisterFlightsServiceServer(s grpc.ServiceRegistrar, srv FlightsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFlightsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FlightsService_ServiceDesc, srv)
+}
+
+func _FlightsService_SearchFlights_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchFlightsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FlightsServiceServer).SearchFlights(m, &grpc.GenericServerStream[SearchFlightsRequest, Flight]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FlightsService_SearchFlightsServer = grpc.ServerStreamingServer[Flight]
+
+// FlightsService_ServiceDesc is the grpc.ServiceDesc for FlightsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FlightsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "simpleapi.v1.FlightsService",
+	HandlerType: (*FlightsServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchFlights",
+			Handler:       _FlightsService_SearchFlights_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "simpleapi/v1/flights.proto",
+}
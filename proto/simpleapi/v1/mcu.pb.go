@@ -0,0 +1,808 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.29.3
+// source: simpleapi/v1/mcu.proto
+
+package simpleapiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Movie struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Formatted as YYYY-MM-DD, or empty if it hasn't been announced.
+	ReleaseDate      string `protobuf:"bytes,3,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	BoxOffice        int64  `protobuf:"varint,4,opt,name=box_office,json=boxOffice,proto3" json:"box_office,omitempty"`
+	DurationMinutes  int32  `protobuf:"varint,5,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	Overview         string `protobuf:"bytes,6,opt,name=overview,proto3" json:"overview,omitempty"`
+	CoverUrl         string `protobuf:"bytes,7,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	TrailerUrl       string `protobuf:"bytes,8,opt,name=trailer_url,json=trailerUrl,proto3" json:"trailer_url,omitempty"`
+	DirectedBy       string `protobuf:"bytes,9,opt,name=directed_by,json=directedBy,proto3" json:"directed_by,omitempty"`
+	Phase            int32  `protobuf:"varint,10,opt,name=phase,proto3" json:"phase,omitempty"`
+	Saga             string `protobuf:"bytes,11,opt,name=saga,proto3" json:"saga,omitempty"`
+	Chronology       int32  `protobuf:"varint,12,opt,name=chronology,proto3" json:"chronology,omitempty"`
+	PostCreditScenes int32  `protobuf:"varint,13,opt,name=post_credit_scenes,json=postCreditScenes,proto3" json:"post_credit_scenes,omitempty"`
+	ImdbId           string `protobuf:"bytes,14,opt,name=imdb_id,json=imdbId,proto3" json:"imdb_id,omitempty"`
+	Upcoming         bool   `protobuf:"varint,15,opt,name=upcoming,proto3" json:"upcoming,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Movie) Reset() {
+	*x = Movie{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Movie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Movie) ProtoMessage() {}
+
+func (x *Movie) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Movie.ProtoReflect.Descriptor instead.
+func (*Movie) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Movie) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Movie) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Movie) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Movie) GetBoxOffice() int64 {
+	if x != nil {
+		return x.BoxOffice
+	}
+	return 0
+}
+
+func (x *Movie) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *Movie) GetOverview() string {
+	if x != nil {
+		return x.Overview
+	}
+	return ""
+}
+
+func (x *Movie) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *Movie) GetTrailerUrl() string {
+	if x != nil {
+		return x.TrailerUrl
+	}
+	return ""
+}
+
+func (x *Movie) GetDirectedBy() string {
+	if x != nil {
+		return x.DirectedBy
+	}
+	return ""
+}
+
+func (x *Movie) GetPhase() int32 {
+	if x != nil {
+		return x.Phase
+	}
+	return 0
+}
+
+func (x *Movie) GetSaga() string {
+	if x != nil {
+		return x.Saga
+	}
+	return ""
+}
+
+func (x *Movie) GetChronology() int32 {
+	if x != nil {
+		return x.Chronology
+	}
+	return 0
+}
+
+func (x *Movie) GetPostCreditScenes() int32 {
+	if x != nil {
+		return x.PostCreditScenes
+	}
+	return 0
+}
+
+func (x *Movie) GetImdbId() string {
+	if x != nil {
+		return x.ImdbId
+	}
+	return ""
+}
+
+func (x *Movie) GetUpcoming() bool {
+	if x != nil {
+		return x.Upcoming
+	}
+	return false
+}
+
+type Aggregates struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Formatted as YYYY-MM-DD, or empty if no release dates are known.
+	StartDate            string  `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate              string  `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	TotalBoxOffice       int64   `protobuf:"varint,3,opt,name=total_box_office,json=totalBoxOffice,proto3" json:"total_box_office,omitempty"`
+	TotalDurationMinutes int32   `protobuf:"varint,4,opt,name=total_duration_minutes,json=totalDurationMinutes,proto3" json:"total_duration_minutes,omitempty"`
+	TotalMovies          int32   `protobuf:"varint,5,opt,name=total_movies,json=totalMovies,proto3" json:"total_movies,omitempty"`
+	AvgPostCreditScenes  float64 `protobuf:"fixed64,6,opt,name=avg_post_credit_scenes,json=avgPostCreditScenes,proto3" json:"avg_post_credit_scenes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Aggregates) Reset() {
+	*x = Aggregates{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Aggregates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregates) ProtoMessage() {}
+
+func (x *Aggregates) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregates.ProtoReflect.Descriptor instead.
+func (*Aggregates) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Aggregates) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Aggregates) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *Aggregates) GetTotalBoxOffice() int64 {
+	if x != nil {
+		return x.TotalBoxOffice
+	}
+	return 0
+}
+
+func (x *Aggregates) GetTotalDurationMinutes() int32 {
+	if x != nil {
+		return x.TotalDurationMinutes
+	}
+	return 0
+}
+
+func (x *Aggregates) GetTotalMovies() int32 {
+	if x != nil {
+		return x.TotalMovies
+	}
+	return 0
+}
+
+func (x *Aggregates) GetAvgPostCreditScenes() float64 {
+	if x != nil {
+		return x.AvgPostCreditScenes
+	}
+	return 0
+}
+
+type Phase struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Aggregates    *Aggregates            `protobuf:"bytes,2,opt,name=aggregates,proto3" json:"aggregates,omitempty"`
+	Movies        []*Movie               `protobuf:"bytes,3,rep,name=movies,proto3" json:"movies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Phase) Reset() {
+	*x = Phase{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Phase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Phase) ProtoMessage() {}
+
+func (x *Phase) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Phase.ProtoReflect.Descriptor instead.
+func (*Phase) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Phase) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Phase) GetAggregates() *Aggregates {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *Phase) GetMovies() []*Movie {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
+type Saga struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Aggregates    *Aggregates            `protobuf:"bytes,3,opt,name=aggregates,proto3" json:"aggregates,omitempty"`
+	Phases        []*Phase               `protobuf:"bytes,4,rep,name=phases,proto3" json:"phases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Saga) Reset() {
+	*x = Saga{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Saga) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Saga) ProtoMessage() {}
+
+func (x *Saga) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Saga.ProtoReflect.Descriptor instead.
+func (*Saga) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Saga) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Saga) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Saga) GetAggregates() *Aggregates {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *Saga) GetPhases() []*Phase {
+	if x != nil {
+		return x.Phases
+	}
+	return nil
+}
+
+type ListMoviesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return movies in the saga, matched by name or slug.
+	Saga string `protobuf:"bytes,1,opt,name=saga,proto3" json:"saga,omitempty"`
+	// Only return movies in any of the phases.
+	Phases []int32 `protobuf:"varint,2,rep,packed,name=phases,proto3" json:"phases,omitempty"`
+	// Only return movies directed by someone whose name contains director.
+	Director      string `protobuf:"bytes,3,opt,name=director,proto3" json:"director,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMoviesRequest) Reset() {
+	*x = ListMoviesRequest{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMoviesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMoviesRequest) ProtoMessage() {}
+
+func (x *ListMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMoviesRequest.ProtoReflect.Descriptor instead.
+func (*ListMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMoviesRequest) GetSaga() string {
+	if x != nil {
+		return x.Saga
+	}
+	return ""
+}
+
+func (x *ListMoviesRequest) GetPhases() []int32 {
+	if x != nil {
+		return x.Phases
+	}
+	return nil
+}
+
+func (x *ListMoviesRequest) GetDirector() string {
+	if x != nil {
+		return x.Director
+	}
+	return ""
+}
+
+type ListMoviesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movies        []*Movie               `protobuf:"bytes,1,rep,name=movies,proto3" json:"movies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMoviesResponse) Reset() {
+	*x = ListMoviesResponse{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMoviesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMoviesResponse) ProtoMessage() {}
+
+func (x *ListMoviesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMoviesResponse.ProtoReflect.Descriptor instead.
+func (*ListMoviesResponse) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMoviesResponse) GetMovies() []*Movie {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
+type GetMovieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMovieRequest) Reset() {
+	*x = GetMovieRequest{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMovieRequest) ProtoMessage() {}
+
+func (x *GetMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMovieRequest.ProtoReflect.Descriptor instead.
+func (*GetMovieRequest) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMovieRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListSagasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSagasRequest) Reset() {
+	*x = ListSagasRequest{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSagasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSagasRequest) ProtoMessage() {}
+
+func (x *ListSagasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSagasRequest.ProtoReflect.Descriptor instead.
+func (*ListSagasRequest) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{7}
+}
+
+type ListSagasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sagas         []*Saga                `protobuf:"bytes,1,rep,name=sagas,proto3" json:"sagas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSagasResponse) Reset() {
+	*x = ListSagasResponse{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSagasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSagasResponse) ProtoMessage() {}
+
+func (x *ListSagasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSagasResponse.ProtoReflect.Descriptor instead.
+func (*ListSagasResponse) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSagasResponse) GetSagas() []*Saga {
+	if x != nil {
+		return x.Sagas
+	}
+	return nil
+}
+
+type GetSagaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSagaRequest) Reset() {
+	*x = GetSagaRequest{}
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSagaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSagaRequest) ProtoMessage() {}
+
+func (x *GetSagaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simpleapi_v1_mcu_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSagaRequest.ProtoReflect.Descriptor instead.
+func (*GetSagaRequest) Descriptor() ([]byte, []int) {
+	return file_simpleapi_v1_mcu_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSagaRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_simpleapi_v1_mcu_proto protoreflect.FileDescriptor
+
+const file_simpleapi_v1_mcu_proto_rawDesc = "" +
+	"\n" +
+	"\x16simpleapi/v1/mcu.proto\x12\fsimpleapi.v1\"\xc2\x03\n" +
+	"\x05Movie\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12!\n" +
+	"\frelease_date\x18\x03 \x01(\tR\vreleaseDate\x12\x1d\n" +
+	"\n" +
+	"box_office\x18\x04 \x01(\x03R\tboxOffice\x12)\n" +
+	"\x10duration_minutes\x18\x05 \x01(\x05R\x0fdurationMinutes\x12\x1a\n" +
+	"\boverview\x18\x06 \x01(\tR\boverview\x12\x1b\n" +
+	"\tcover_url\x18\a \x01(\tR\bcoverUrl\x12\x1f\n" +
+	"\vtrailer_url\x18\b \x01(\tR\n" +
+	"trailerUrl\x12\x1f\n" +
+	"\vdirected_by\x18\t \x01(\tR\n" +
+	"directedBy\x12\x14\n" +
+	"\x05phase\x18\n" +
+	" \x01(\x05R\x05phase\x12\x12\n" +
+	"\x04saga\x18\v \x01(\tR\x04saga\x12\x1e\n" +
+	"\n" +
+	"chronology\x18\f \x01(\x05R\n" +
+	"chronology\x12,\n" +
+	"\x12post_credit_scenes\x18\r \x01(\x05R\x10postCreditScenes\x12\x17\n" +
+	"\aimdb_id\x18\x0e \x01(\tR\x06imdbId\x12\x1a\n" +
+	"\bupcoming\x18\x0f \x01(\bR\bupcoming\"\xfe\x01\n" +
+	"\n" +
+	"Aggregates\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12(\n" +
+	"\x10total_box_office\x18\x03 \x01(\x03R\x0etotalBoxOffice\x124\n" +
+	"\x16total_duration_minutes\x18\x04 \x01(\x05R\x14totalDurationMinutes\x12!\n" +
+	"\ftotal_movies\x18\x05 \x01(\x05R\vtotalMovies\x123\n" +
+	"\x16avg_post_credit_scenes\x18\x06 \x01(\x01R\x13avgPostCreditScenes\"\x86\x01\n" +
+	"\x05Phase\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x128\n" +
+	"\n" +
+	"aggregates\x18\x02 \x01(\v2\x18.simpleapi.v1.AggregatesR\n" +
+	"aggregates\x12+\n" +
+	"\x06movies\x18\x03 \x03(\v2\x13.simpleapi.v1.MovieR\x06movies\"\x95\x01\n" +
+	"\x04Saga\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x128\n" +
+	"\n" +
+	"aggregates\x18\x03 \x01(\v2\x18.simpleapi.v1.AggregatesR\n" +
+	"aggregates\x12+\n" +
+	"\x06phases\x18\x04 \x03(\v2\x13.simpleapi.v1.PhaseR\x06phases\"[\n" +
+	"\x11ListMoviesRequest\x12\x12\n" +
+	"\x04saga\x18\x01 \x01(\tR\x04saga\x12\x16\n" +
+	"\x06phases\x18\x02 \x03(\x05R\x06phases\x12\x1a\n" +
+	"\bdirector\x18\x03 \x01(\tR\bdirector\"A\n" +
+	"\x12ListMoviesResponse\x12+\n" +
+	"\x06movies\x18\x01 \x03(\v2\x13.simpleapi.v1.MovieR\x06movies\"!\n" +
+	"\x0fGetMovieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x12\n" +
+	"\x10ListSagasRequest\"=\n" +
+	"\x11ListSagasResponse\x12(\n" +
+	"\x05sagas\x18\x01 \x03(\v2\x12.simpleapi.v1.SagaR\x05sagas\"$\n" +
+	"\x0eGetSagaRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name2\xa8\x02\n" +
+	"\n" +
+	"MCUService\x12O\n" +
+	"\n" +
+	"ListMovies\x12\x1f.simpleapi.v1.ListMoviesRequest\x1a .simpleapi.v1.ListMoviesResponse\x12>\n" +
+	"\bGetMovie\x12\x1d.simpleapi.v1.GetMovieRequest\x1a\x13.simpleapi.v1.Movie\x12L\n" +
+	"\tListSagas\x12\x1e.simpleapi.v1.ListSagasRequest\x1a\x1f.simpleapi.v1.ListSagasResponse\x12;\n" +
+	"\aGetSaga\x12\x1c.simpleapi.v1.GetSagaRequest\x1a\x12.simpleapi.v1.SagaB>Z<github.com/jace-ys/simple-api/proto/simpleapi/v1;simpleapiv1b\x06proto3"
+
+var (
+	file_simpleapi_v1_mcu_proto_rawDescOnce sync.Once
+	file_simpleapi_v1_mcu_proto_rawDescData []byte
+)
+
+func file_simpleapi_v1_mcu_proto_rawDescGZIP() []byte {
+	file_simpleapi_v1_mcu_proto_rawDescOnce.Do(func() {
+		file_simpleapi_v1_mcu_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_simpleapi_v1_mcu_proto_rawDesc), len(file_simpleapi_v1_mcu_proto_rawDesc)))
+	})
+	return file_simpleapi_v1_mcu_proto_rawDescData
+}
+
+var file_simpleapi_v1_mcu_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_simpleapi_v1_mcu_proto_goTypes = []any{
+	(*Movie)(nil),              // 0: simpleapi.v1.Movie
+	(*Aggregates)(nil),         // 1: simpleapi.v1.Aggregates
+	(*Phase)(nil),              // 2: simpleapi.v1.Phase
+	(*Saga)(nil),               // 3: simpleapi.v1.Saga
+	(*ListMoviesRequest)(nil),  // 4: simpleapi.v1.ListMoviesRequest
+	(*ListMoviesResponse)(nil), // 5: simpleapi.v1.ListMoviesResponse
+	(*GetMovieRequest)(nil),    // 6: simpleapi.v1.GetMovieRequest
+	(*ListSagasRequest)(nil),   // 7: simpleapi.v1.ListSagasRequest
+	(*ListSagasResponse)(nil),  // 8: simpleapi.v1.ListSagasResponse
+	(*GetSagaRequest)(nil),     // 9: simpleapi.v1.GetSagaRequest
+}
+var file_simpleapi_v1_mcu_proto_depIdxs = []int32{
+	1,  // 0: simpleapi.v1.Phase.aggregates:type_name -> simpleapi.v1.Aggregates
+	0,  // 1: simpleapi.v1.Phase.movies:type_name -> simpleapi.v1.Movie
+	1,  // 2: simpleapi.v1.Saga.aggregates:type_name -> simpleapi.v1.Aggregates
+	2,  // 3: simpleapi.v1.Saga.phases:type_name -> simpleapi.v1.Phase
+	0,  // 4: simpleapi.v1.ListMoviesResponse.movies:type_name -> simpleapi.v1.Movie
+	3,  // 5: simpleapi.v1.ListSagasResponse.sagas:type_name -> simpleapi.v1.Saga
+	4,  // 6: simpleapi.v1.MCUService.ListMovies:input_type -> simpleapi.v1.ListMoviesRequest
+	6,  // 7: simpleapi.v1.MCUService.GetMovie:input_type -> simpleapi.v1.GetMovieRequest
+	7,  // 8: simpleapi.v1.MCUService.ListSagas:input_type -> simpleapi.v1.ListSagasRequest
+	9,  // 9: simpleapi.v1.MCUService.GetSaga:input_type -> simpleapi.v1.GetSagaRequest
+	5,  // 10: simpleapi.v1.MCUService.ListMovies:output_type -> simpleapi.v1.ListMoviesResponse
+	0,  // 11: simpleapi.v1.MCUService.GetMovie:output_type -> simpleapi.v1.Movie
+	8,  // 12: simpleapi.v1.MCUService.ListSagas:output_type -> simpleapi.v1.ListSagasResponse
+	3,  // 13: simpleapi.v1.MCUService.GetSaga:output_type -> simpleapi.v1.Saga
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_simpleapi_v1_mcu_proto_init() }
+func file_simpleapi_v1_mcu_proto_init() {
+	if File_simpleapi_v1_mcu_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_simpleapi_v1_mcu_proto_rawDesc), len(file_simpleapi_v1_mcu_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_simpleapi_v1_mcu_proto_goTypes,
+		DependencyIndexes: file_simpleapi_v1_mcu_proto_depIdxs,
+		MessageInfos:      file_simpleapi_v1_mcu_proto_msgTypes,
+	}.Build()
+	File_simpleapi_v1_mcu_proto = out.File
+	file_simpleapi_v1_mcu_proto_goTypes = nil
+	file_simpleapi_v1_mcu_proto_depIdxs = nil
+}
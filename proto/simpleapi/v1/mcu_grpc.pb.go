@@ -0,0 +1,253 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: simpleapi/v1/mcu.proto
+
+package simpleapiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MCUService_ListMovies_FullMethodName = "/simpleapi.v1.MCUService/ListMovies"
+	MCUService_GetMovie_FullMethodName   = "/simpleapi.v1.MCUService/GetMovie"
+	MCUService_ListSagas_FullMethodName  = "/simpleapi.v1.MCUService/ListSagas"
+	MCUService_GetSaga_FullMethodName    = "/simpleapi.v1.MCUService/GetSaga"
+)
+
+// MCUServiceClient is the client API for MCUService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MCUService serves movies of the Marvel Cinematic Universe, grouped into
+// sagas and phases.
+type MCUServiceClient interface {
+	// ListMovies returns movies in release order, optionally filtered.
+	ListMovies(ctx context.Context, in *ListMoviesRequest, opts ...grpc.CallOption) (*ListMoviesResponse, error)
+	// GetMovie returns a single movie, failing with NOT_FOUND if it doesn't
+	// exist.
+	GetMovie(ctx context.Context, in *GetMovieRequest, opts ...grpc.CallOption) (*Movie, error)
+	// ListSagas returns every saga with its phases and movies.
+	ListSagas(ctx context.Context, in *ListSagasRequest, opts ...grpc.CallOption) (*ListSagasResponse, error)
+	// GetSaga returns a single saga by name or slug, failing with NOT_FOUND if
+	// it doesn't exist.
+	GetSaga(ctx context.Context, in *GetSagaRequest, opts ...grpc.CallOption) (*Saga, error)
+}
+
+type mCUServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMCUServiceClient(cc grpc.ClientConnInterface) MCUServiceClient {
+	return &mCUServiceClient{cc}
+}
+
+func (c *mCUServiceClient) ListMovies(ctx context.Context, in *ListMoviesRequest, opts ...grpc.CallOption) (*ListMoviesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMoviesResponse)
+	err := c.cc.Invoke(ctx, MCUService_ListMovies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mCUServiceClient) GetMovie(ctx context.Context, in *GetMovieRequest, opts ...grpc.CallOption) (*Movie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Movie)
+	err := c.cc.Invoke(ctx, MCUService_GetMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mCUServiceClient) ListSagas(ctx context.Context, in *ListSagasRequest, opts ...grpc.CallOption) (*ListSagasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSagasResponse)
+	err := c.cc.Invoke(ctx, MCUService_ListSagas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mCUServiceClient) GetSaga(ctx context.Context, in *GetSagaRequest, opts ...grpc.CallOption) (*Saga, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Saga)
+	err := c.cc.Invoke(ctx, MCUService_GetSaga_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MCUServiceServer is the server API for MCUService service.
+// All implementations must embed UnimplementedMCUServiceServer
+// for forward compatibility.
+//
+// MCUService serves movies of the Marvel Cinematic Universe, grouped into
+// sagas and phases.
+type MCUServiceServer interface {
+	// ListMovies returns movies in release order, optionally filtered.
+	ListMovies(context.Context, *ListMoviesRequest) (*ListMoviesResponse, error)
+	// GetMovie returns a single movie, failing with NOT_FOUND if it doesn't
+	// exist.
+	GetMovie(context.Context, *GetMovieRequest) (*Movie, error)
+	// ListSagas returns every saga with its phases and movies.
+	ListSagas(context.Context, *ListSagasRequest) (*ListSagasResponse, error)
+	// GetSaga returns a single saga by name or slug, failing with NOT_FOUND if
+	// it doesn't exist.
+	GetSaga(context.Context, *GetSagaRequest) (*Saga, error)
+	mustEmbedUnimplementedMCUServiceServer()
+}
+
+// UnimplementedMCUServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMCUServiceServer struct{}
+
+func (UnimplementedMCUServiceServer) ListMovies(context.Context, *ListMoviesRequest) (*ListMoviesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMovies not implemented")
+}
+func (UnimplementedMCUServiceServer) GetMovie(context.Context, *GetMovieRequest) (*Movie, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMovie not implemented")
+}
+func (UnimplementedMCUServiceServer) ListSagas(context.Context, *ListSagasRequest) (*ListSagasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSagas not implemented")
+}
+func (UnimplementedMCUServiceServer) GetSaga(context.Context, *GetSagaRequest) (*Saga, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSaga not implemented")
+}
+func (UnimplementedMCUServiceServer) mustEmbedUnimplementedMCUServiceServer() {}
+func (UnimplementedMCUServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeMCUServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MCUServiceServer will
+// result in compilation errors.
+type UnsafeMCUServiceServer interface {
+	mustEmbedUnimplementedMCUServiceServer()
+}
+
+func RegisterMCUServiceServer(s grpc.ServiceRegistrar, srv MCUServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMCUServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MCUService_ServiceDesc, srv)
+}
+
+func _MCUService_ListMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMoviesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MCUServiceServer).ListMovies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MCUService_ListMovies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MCUServiceServer).ListMovies(ctx, req.(*ListMoviesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MCUService_GetMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MCUServiceServer).GetMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MCUService_GetMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MCUServiceServer).GetMovie(ctx, req.(*GetMovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MCUService_ListSagas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSagasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MCUServiceServer).ListSagas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MCUService_ListSagas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MCUServiceServer).ListSagas(ctx, req.(*ListSagasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MCUService_GetSaga_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSagaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MCUServiceServer).GetSaga(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MCUService_GetSaga_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MCUServiceServer).GetSaga(ctx, req.(*GetSagaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MCUService_ServiceDesc is the grpc.ServiceDesc for MCUService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MCUService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "simpleapi.v1.MCUService",
+	HandlerType: (*MCUServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMovies",
+			Handler:    _MCUService_ListMovies_Handler,
+		},
+		{
+			MethodName: "GetMovie",
+			Handler:    _MCUService_GetMovie_Handler,
+		},
+		{
+			MethodName: "ListSagas",
+			Handler:    _MCUService_ListSagas_Handler,
+		},
+		{
+			MethodName: "GetSaga",
+			Handler:    _MCUService_GetSaga_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "simpleapi/v1/mcu.proto",
+}
@@ -0,0 +1,222 @@
+// Package recommend suggests movies similar to ones a user has watched.
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/search"
+)
+
+// Weights of each signal in a recommendation's score, which add up to one.
+const (
+	weightSaga       = 0.15
+	weightPhase      = 0.2
+	weightChronology = 0.25
+	weightDirector   = 0.15
+	weightOverview   = 0.25
+
+	// minOverviewSimilarity is the cosine similarity above which overviews
+	// are worth mentioning as a reason.
+	minOverviewSimilarity = 0.1
+	maxSharedTerms        = 3
+)
+
+type Recommendation struct {
+	Movie   *domain.Movie `json:"movie"`
+	Score   float64       `json:"score"`
+	Reasons []string      `json:"reasons"`
+}
+
+// Engine scores movies against each other by saga and phase proximity,
+// chronology adjacency, shared director and TF-IDF similarity of overviews.
+type Engine struct {
+	movies  domain.Movies
+	byID    map[int]*domain.Movie
+	vectors map[int]map[string]float64
+}
+
+func NewEngine(movies domain.Movies) *Engine {
+	e := &Engine{
+		movies:  movies,
+		byID:    make(map[int]*domain.Movie, len(movies)),
+		vectors: make(map[int]map[string]float64, len(movies)),
+	}
+
+	tfs := make(map[int]map[string]int, len(movies))
+	df := make(map[string]int)
+	for _, movie := range movies {
+		e.byID[movie.ID] = movie
+
+		tf := make(map[string]int)
+		for _, term := range search.Terms(movie.Overview) {
+			tf[term]++
+		}
+		for term := range tf {
+			df[term]++
+		}
+		tfs[movie.ID] = tf
+	}
+
+	n := float64(len(movies))
+	for id, tf := range tfs {
+		vec := make(map[string]float64, len(tf))
+		var norm float64
+		for term, count := range tf {
+			w := float64(count) * math.Log(1+n/float64(df[term]))
+			vec[term] = w
+			norm += w * w
+		}
+		norm = math.Sqrt(norm)
+		for term := range vec {
+			vec[term] /= norm
+		}
+		e.vectors[id] = vec
+	}
+
+	return e
+}
+
+// Recommend returns up to limit movies to watch after the seed movies, best
+// first. Each candidate is scored against its closest seed, and seeds are
+// never recommended themselves. Unknown seed IDs are ignored.
+func (e *Engine) Recommend(seedIDs []int, limit int) []*Recommendation {
+	seeds := make(domain.Movies, 0, len(seedIDs))
+	isSeed := make(map[int]bool, len(seedIDs))
+	for _, id := range seedIDs {
+		if movie, ok := e.byID[id]; ok && !isSeed[id] {
+			seeds = append(seeds, movie)
+			isSeed[id] = true
+		}
+	}
+
+	recs := []*Recommendation{}
+	if len(seeds) == 0 {
+		return recs
+	}
+
+	for _, candidate := range e.movies {
+		if isSeed[candidate.ID] {
+			continue
+		}
+
+		var best *Recommendation
+		for _, seed := range seeds {
+			rec := e.score(seed, candidate, len(seeds) > 1)
+			if best == nil || rec.Score > best.Score {
+				best = rec
+			}
+		}
+		recs = append(recs, best)
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].Movie.Chronology < recs[j].Movie.Chronology
+	})
+
+	if limit > 0 && len(recs) > limit {
+		recs = recs[:limit]
+	}
+	return recs
+}
+
+func (e *Engine) score(seed, candidate *domain.Movie, namedSeed bool) *Recommendation {
+	rec := &Recommendation{Movie: candidate, Reasons: []string{}}
+	var score float64
+
+	if namedSeed {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("Because you watched %s", seed.Title))
+	}
+
+	if seed.Saga != "" && seed.Saga == candidate.Saga {
+		score += weightSaga
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("Also part of the %s", seed.Saga))
+	}
+
+	if seed.Phase > 0 && candidate.Phase > 0 {
+		dist := abs(seed.Phase - candidate.Phase)
+		score += weightPhase / float64(1+dist)
+		switch dist {
+		case 0:
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("Same phase (Phase %d)", seed.Phase))
+		case 1:
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("Adjacent phase (Phase %d)", candidate.Phase))
+		}
+	}
+
+	if seed.Chronology > 0 && candidate.Chronology > 0 && seed.Chronology != candidate.Chronology {
+		dist := candidate.Chronology - seed.Chronology
+		chronology := weightChronology / float64(abs(dist))
+		if dist < 0 {
+			// Movies set before the seed are less likely to be watched next.
+			chronology /= 2
+		}
+		score += chronology
+		switch dist {
+		case 1:
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("Next in chronological order after %s", seed.Title))
+		case -1:
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("Directly precedes %s chronologically", seed.Title))
+		}
+	}
+
+	if seed.DirectedBy != "" && strings.EqualFold(seed.DirectedBy, candidate.DirectedBy) {
+		score += weightDirector
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("Also directed by %s", seed.DirectedBy))
+	}
+
+	similarity, shared := e.similarity(seed.ID, candidate.ID)
+	score += weightOverview * similarity
+	if similarity >= minOverviewSimilarity && len(shared) > 0 {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("Similar story: %s", strings.Join(shared, ", ")))
+	}
+
+	rec.Score = math.Round(score*1000) / 1000
+	return rec
+}
+
+// similarity returns the cosine similarity of two movies' overviews, along
+// with the terms that contribute to it most.
+func (e *Engine) similarity(a, b int) (float64, []string) {
+	va, vb := e.vectors[a], e.vectors[b]
+
+	type contribution struct {
+		term   string
+		weight float64
+	}
+
+	var sim float64
+	var contributions []contribution
+	for term, wa := range va {
+		if wb, ok := vb[term]; ok {
+			sim += wa * wb
+			contributions = append(contributions, contribution{term, wa * wb})
+		}
+	}
+
+	sort.Slice(contributions, func(i, j int) bool {
+		if contributions[i].weight != contributions[j].weight {
+			return contributions[i].weight > contributions[j].weight
+		}
+		return contributions[i].term < contributions[j].term
+	})
+
+	var shared []string
+	for i := 0; i < len(contributions) && i < maxSharedTerms; i++ {
+		shared = append(shared, contributions[i].term)
+	}
+	return sim, shared
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
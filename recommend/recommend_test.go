@@ -0,0 +1,59 @@
+package recommend_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/recommend"
+)
+
+var movies = domain.Movies{
+	{ID: 1, Title: "Iron Man", Phase: 1, Saga: "Infinity Saga", Chronology: 3, DirectedBy: "Jon Favreau", Overview: "Tony Stark builds a suit of armor to escape captivity."},
+	{ID: 2, Title: "Iron Man 2", Phase: 1, Saga: "Infinity Saga", Chronology: 4, DirectedBy: "Jon Favreau", Overview: "Tony Stark faces a rival using his own armor technology."},
+	{ID: 3, Title: "Captain America", Phase: 1, Saga: "Infinity Saga", Chronology: 1, DirectedBy: "Joe Johnston", Overview: "Steve Rogers becomes a super soldier in the war."},
+	{ID: 4, Title: "Thor", Phase: 1, Saga: "Infinity Saga", Chronology: 5, DirectedBy: "Kenneth Branagh", Overview: "The god of thunder is cast out of Asgard."},
+	{ID: 5, Title: "Eternals", Phase: 4, Saga: "Multiverse Saga", Chronology: 30, DirectedBy: "Chloé Zhao", Overview: "Immortal beings emerge from hiding."},
+}
+
+func TestRecommendForMovie(t *testing.T) {
+	engine := recommend.NewEngine(movies)
+
+	recs := engine.Recommend([]int{1}, 3)
+	assert.Len(t, recs, 3)
+
+	assert.Equal(t, 2, recs[0].Movie.ID)
+	assert.Equal(t, []string{
+		"Also part of the Infinity Saga",
+		"Same phase (Phase 1)",
+		"Next in chronological order after Iron Man",
+		"Also directed by Jon Favreau",
+		"Similar story: armor, stark, tony",
+	}, recs[0].Reasons)
+
+	for i := 1; i < len(recs); i++ {
+		assert.GreaterOrEqual(t, recs[i-1].Score, recs[i].Score)
+		assert.NotEqual(t, 1, recs[i].Movie.ID, "seeds aren't recommended")
+	}
+
+	all := engine.Recommend([]int{1}, 0)
+	assert.Equal(t, 5, all[len(all)-1].Movie.ID, "movies in another saga rank last")
+}
+
+func TestRecommendForWatched(t *testing.T) {
+	engine := recommend.NewEngine(movies)
+
+	recs := engine.Recommend([]int{3, 1, 2}, 0)
+	assert.Len(t, recs, 2)
+	assert.Equal(t, 4, recs[0].Movie.ID)
+	assert.Contains(t, recs[0].Reasons, "Because you watched Iron Man 2")
+	assert.Contains(t, recs[0].Reasons, "Next in chronological order after Iron Man 2")
+}
+
+func TestRecommendUnknownSeeds(t *testing.T) {
+	engine := recommend.NewEngine(movies)
+
+	assert.Empty(t, engine.Recommend([]int{99}, 0))
+	assert.Empty(t, engine.Recommend(nil, 0))
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/logging"
+	pb "github.com/jace-ys/simple-api/proto/simpleapi/v1"
+)
+
+// Supplier is an airline that flights are searched from.
+type Supplier struct {
+	Name    string
+	Flights domain.FlightsService
+}
+
+type FlightsServer struct {
+	pb.UnimplementedFlightsServiceServer
+	suppliers []Supplier
+}
+
+func NewFlightsServer(suppliers ...Supplier) *FlightsServer {
+	return &FlightsServer{
+		suppliers: suppliers,
+	}
+}
+
+type supplierResult struct {
+	supplier string
+	flights  domain.DuffelFlights
+	err      error
+}
+
+// SearchFlights searches every supplier concurrently, sending each supplier's
+// flights sorted by price as soon as they arrive.
+func (s *FlightsServer) SearchFlights(req *pb.SearchFlightsRequest, stream pb.FlightsService_SearchFlightsServer) error {
+	departureDate, err := time.Parse("2006-01-02", req.GetDepartureDate())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "Invalid departure date, must be of format YYYY-MM-DD")
+	}
+
+	switch {
+	case len(req.GetOrigin()) > 3:
+		return status.Error(codes.InvalidArgument, "Invalid airport code for origin")
+	case len(req.GetDestination()) > 3:
+		return status.Error(codes.InvalidArgument, "Invalid airport code for destination")
+	}
+
+	ctx := stream.Context()
+	results := make(chan supplierResult, len(s.suppliers))
+	for _, supplier := range s.suppliers {
+		go func() {
+			flights, err := supplier.Flights.GetFlights(ctx, req.GetOrigin(), req.GetDestination(), departureDate.Format("2006-01-02"))
+			results <- supplierResult{supplier: supplier.Name, flights: flights, err: err}
+		}()
+	}
+
+	var failed int
+	for range s.suppliers {
+		result := <-results
+		if result.err != nil {
+			logging.FromContext(ctx).Error("GetFlights request error", slog.Any("error", result.err), slog.String("supplier", result.supplier))
+			failed++
+			continue
+		}
+
+		for _, flight := range result.flights.SortByPrice(domain.SortAsc) {
+			if err := stream.Send(toFlight(flight, result.supplier)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(s.suppliers) > 0 && failed == len(s.suppliers) {
+		return status.Error(codes.Internal, "Internal server error")
+	}
+	return nil
+}
+
+func toFlight(f *domain.DuffelFlight, supplier string) *pb.Flight {
+	return &pb.Flight{
+		ArrivalTime:     timestamppb.New(f.ArrivalTime),
+		DepartureTime:   timestamppb.New(f.DepartureTime),
+		DurationMinutes: int32(f.DurationMinutes),
+		TotalAmount:     f.TotalAmount,
+		Currency:        f.Currency,
+		FlightNumber:    f.FlightNumber,
+		Origin:          f.Origin,
+		Destination:     f.Destination,
+		Supplier:        supplier,
+	}
+}
@@ -0,0 +1,147 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/logging"
+	pb "github.com/jace-ys/simple-api/proto/simpleapi/v1"
+)
+
+type MCUServer struct {
+	pb.UnimplementedMCUServiceServer
+	movies domain.MoviesService
+}
+
+func NewMCUServer(movies domain.MoviesService) *MCUServer {
+	return &MCUServer{
+		movies: movies,
+	}
+}
+
+func (s *MCUServer) ListMovies(ctx context.Context, req *pb.ListMoviesRequest) (*pb.ListMoviesResponse, error) {
+	movies, err := s.movies.GetMovies(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("GetMovies request error", slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	filter := domain.MovieFilter{
+		Saga:     req.GetSaga(),
+		Director: req.GetDirector(),
+	}
+	for _, phase := range req.GetPhases() {
+		filter.Phases = append(filter.Phases, int(phase))
+	}
+
+	return &pb.ListMoviesResponse{Movies: toMovies(movies.Filter(filter))}, nil
+}
+
+func (s *MCUServer) GetMovie(ctx context.Context, req *pb.GetMovieRequest) (*pb.Movie, error) {
+	movie, err := s.movies.GetMovie(ctx, int(req.GetId()))
+	if err != nil {
+		logging.FromContext(ctx).Error("GetMovie request error", slog.Any("error", err), slog.Int("movie_id", int(req.GetId())))
+		switch {
+		case errors.Is(err, domain.ErrMovieNotFound):
+			return nil, status.Error(codes.NotFound, "Movie not found")
+		default:
+			return nil, status.Error(codes.Internal, "Internal server error")
+		}
+	}
+
+	return toMovie(movie), nil
+}
+
+func (s *MCUServer) ListSagas(ctx context.Context, req *pb.ListSagasRequest) (*pb.ListSagasResponse, error) {
+	movies, err := s.movies.GetMovies(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("GetMovies request error", slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	res := &pb.ListSagasResponse{}
+	for _, saga := range movies.GroupBySaga() {
+		res.Sagas = append(res.Sagas, toSaga(saga))
+	}
+	return res, nil
+}
+
+func (s *MCUServer) GetSaga(ctx context.Context, req *pb.GetSagaRequest) (*pb.Saga, error) {
+	movies, err := s.movies.GetMovies(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("GetMovies request error", slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	saga, err := movies.GetSaga(req.GetName())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSagaNotFound):
+			return nil, status.Error(codes.NotFound, "Saga not found")
+		default:
+			return nil, status.Error(codes.Internal, "Internal server error")
+		}
+	}
+
+	return toSaga(saga), nil
+}
+
+func toMovies(movies domain.Movies) []*pb.Movie {
+	res := make([]*pb.Movie, 0, len(movies))
+	for _, movie := range movies {
+		res = append(res, toMovie(movie))
+	}
+	return res
+}
+
+func toMovie(m *domain.Movie) *pb.Movie {
+	return &pb.Movie{
+		Id:               int32(m.ID),
+		Title:            m.Title,
+		ReleaseDate:      m.ReleaseDate.String(),
+		BoxOffice:        int64(m.BoxOffice),
+		DurationMinutes:  int32(m.DurationMinutes),
+		Overview:         m.Overview,
+		CoverUrl:         m.CoverURL,
+		TrailerUrl:       m.TrailerURL,
+		DirectedBy:       m.DirectedBy,
+		Phase:            int32(m.Phase),
+		Saga:             m.Saga,
+		Chronology:       int32(m.Chronology),
+		PostCreditScenes: int32(m.PostCreditScenes),
+		ImdbId:           m.ImdbID,
+		Upcoming:         m.IsUpcoming(domain.Today()),
+	}
+}
+
+func toAggregates(a domain.Aggregates) *pb.Aggregates {
+	return &pb.Aggregates{
+		StartDate:            a.StartDate.String(),
+		EndDate:              a.EndDate.String(),
+		TotalBoxOffice:       int64(a.TotalBoxOffice),
+		TotalDurationMinutes: int32(a.TotalDurationMinutes),
+		TotalMovies:          int32(a.TotalMovies),
+		AvgPostCreditScenes:  a.AvgPostCreditScenes,
+	}
+}
+
+func toSaga(s *domain.Saga) *pb.Saga {
+	saga := &pb.Saga{
+		Name:       s.Name,
+		Slug:       s.Slug,
+		Aggregates: toAggregates(s.Aggregates),
+	}
+	for _, phase := range s.Phases {
+		saga.Phases = append(saga.Phases, &pb.Phase{
+			Number:     int32(phase.Number),
+			Aggregates: toAggregates(phase.Aggregates),
+			Movies:     toMovies(phase.Movies),
+		})
+	}
+	return saga
+}
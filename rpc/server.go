@@ -0,0 +1,126 @@
+// Package rpc serves the movie and flight operations over gRPC.
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/logging"
+	pb "github.com/jace-ys/simple-api/proto/simpleapi/v1"
+)
+
+const requestIDKey = "x-request-id"
+
+// Server is a gRPC server for the MCU and flights services, which also
+// serves the standard health checking service.
+type Server struct {
+	grpc   *grpc.Server
+	health *health.Server
+}
+
+func NewServer(logger *slog.Logger, movies domain.MoviesService, suppliers ...Supplier) *Server {
+	s := &Server{
+		grpc: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(logUnary(logger)),
+			grpc.ChainStreamInterceptor(logStream(logger)),
+		),
+		health: health.NewServer(),
+	}
+
+	pb.RegisterMCUServiceServer(s.grpc, NewMCUServer(movies))
+	pb.RegisterFlightsServiceServer(s.grpc, NewFlightsServer(suppliers...))
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+
+	for service := range s.grpc.GetServiceInfo() {
+		s.health.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	return s
+}
+
+// Serve accepts connections on lis until the server is shut down.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// Shutdown reports every service as not serving, then waits for in-flight
+// RPCs to complete. If ctx is done first, remaining RPCs are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
+
+// logUnary annotates the context of each RPC with a request-scoped logger
+// and emits a log record once it has been handled, like the HTTP server's
+// access log. A request ID sent by the caller is reused.
+func logUnary(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, done := logRPC(ctx, logger, info.FullMethod)
+		res, err := handler(ctx, req)
+		done(err)
+		return res, err
+	}
+}
+
+func logStream(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, done := logRPC(ss.Context(), logger, info.FullMethod)
+		err := handler(srv, &loggedStream{ServerStream: ss, ctx: ctx})
+		done(err)
+		return err
+	}
+}
+
+func logRPC(ctx context.Context, logger *slog.Logger, method string) (context.Context, func(error)) {
+	requestID := logging.NewRequestID()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDKey); len(ids) > 0 && ids[0] != "" {
+			requestID = ids[0]
+		}
+	}
+
+	reqLogger := logger.With(
+		slog.String("request_id", requestID),
+		slog.String("method", method),
+	)
+	ctx = logging.WithLogger(ctx, reqLogger)
+
+	start := time.Now()
+	return ctx, func(err error) {
+		reqLogger.LogAttrs(ctx, slog.LevelInfo, "rpc handled",
+			slog.String("code", status.Code(err).String()),
+			slog.Duration("duration", time.Since(start)),
+		)
+	}
+}
+
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
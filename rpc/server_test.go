@@ -0,0 +1,241 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	pb "github.com/jace-ys/simple-api/proto/simpleapi/v1"
+	"github.com/jace-ys/simple-api/rpc"
+)
+
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// dial serves srv over an in-memory listener and returns a connection to it.
+func dial(t *testing.T, srv *rpc.Server) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Shutdown(context.Background())
+	})
+	return conn
+}
+
+func TestGetMovie(t *testing.T) {
+	tt := []struct {
+		Name          string
+		SetupFake     func(fake *domainfakes.FakeMoviesService)
+		ExpectedCode  codes.Code
+		ExpectedMovie *pb.Movie
+	}{
+		{
+			Name: "Returns the movie",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMovieReturns(&domain.Movie{ID: 1, Title: "Iron Man", ReleaseDate: domain.MustParseDate("2008-05-02"), BoxOffice: 585171547, Phase: 1, Saga: "Infinity Saga"}, nil)
+			},
+			ExpectedCode:  codes.OK,
+			ExpectedMovie: &pb.Movie{Id: 1, Title: "Iron Man", ReleaseDate: "2008-05-02", BoxOffice: 585171547, Phase: 1, Saga: "Infinity Saga"},
+		},
+		{
+			Name: "Returns upcoming movies without a release date",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMovieReturns(&domain.Movie{ID: 1, Title: "Avengers: Secret Wars"}, nil)
+			},
+			ExpectedCode:  codes.OK,
+			ExpectedMovie: &pb.Movie{Id: 1, Title: "Avengers: Secret Wars", Upcoming: true},
+		},
+		{
+			Name: "Returns NOT_FOUND when the movie doesn't exist",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMovieReturns(nil, domain.ErrMovieNotFound)
+			},
+			ExpectedCode: codes.NotFound,
+		},
+		{
+			Name: "Returns INTERNAL when service request fails",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMovieReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			movies := new(domainfakes.FakeMoviesService)
+			tc.SetupFake(movies)
+
+			client := pb.NewMCUServiceClient(dial(t, rpc.NewServer(logger, movies)))
+
+			movie, err := client.GetMovie(context.Background(), &pb.GetMovieRequest{Id: 1})
+			assert.Equal(t, tc.ExpectedCode, status.Code(err))
+			if tc.ExpectedMovie != nil {
+				assert.Equal(t, tc.ExpectedMovie.String(), movie.String())
+			}
+
+			_, movieID := movies.GetMovieArgsForCall(0)
+			assert.Equal(t, 1, movieID)
+		})
+	}
+}
+
+func TestListMovies(t *testing.T) {
+	movies := new(domainfakes.FakeMoviesService)
+	movies.GetMoviesReturns(domain.Movies{
+		{ID: 1, Title: "Iron Man", ReleaseDate: domain.MustParseDate("2008-05-02"), Phase: 1, Saga: "Infinity Saga"},
+		{ID: 2, Title: "Black Widow", ReleaseDate: domain.MustParseDate("2021-07-09"), Phase: 4, Saga: "Multiverse Saga"},
+		{ID: 3, Title: "Eternals", ReleaseDate: domain.MustParseDate("2021-11-05"), Phase: 4, Saga: "Multiverse Saga"},
+	}, nil)
+
+	client := pb.NewMCUServiceClient(dial(t, rpc.NewServer(logger, movies)))
+
+	res, err := client.ListMovies(context.Background(), &pb.ListMoviesRequest{Saga: "multiverse-saga", Phases: []int32{4}})
+	assert.NoError(t, err)
+
+	var ids []int32
+	for _, movie := range res.GetMovies() {
+		ids = append(ids, movie.GetId())
+	}
+	assert.Equal(t, []int32{2, 3}, ids)
+
+	sagas, err := client.ListSagas(context.Background(), &pb.ListSagasRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, sagas.GetSagas(), 2) {
+		saga := sagas.GetSagas()[1]
+		assert.Equal(t, "Multiverse Saga", saga.GetName())
+		assert.Equal(t, "2021-07-09", saga.GetAggregates().GetStartDate())
+		assert.Equal(t, int32(2), saga.GetAggregates().GetTotalMovies())
+		assert.Len(t, saga.GetPhases(), 1)
+	}
+
+	_, err = client.GetSaga(context.Background(), &pb.GetSagaRequest{Name: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSearchFlights(t *testing.T) {
+	departure := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		Name            string
+		Request         *pb.SearchFlightsRequest
+		SetupFakes      func(a, b *domainfakes.FakeFlightsService)
+		ExpectedCode    codes.Code
+		ExpectedFlights map[string]string
+	}{
+		{
+			Name:    "Streams flights from every supplier",
+			Request: &pb.SearchFlightsRequest{Origin: "LHR", Destination: "JFK", DepartureDate: "2025-03-01"},
+			SetupFakes: func(a, b *domainfakes.FakeFlightsService) {
+				a.GetFlightsReturns(domain.DuffelFlights{{FlightNumber: "A1", TotalAmount: 250, DepartureTime: departure}}, nil)
+				b.GetFlightsReturns(domain.DuffelFlights{{FlightNumber: "B1", TotalAmount: 120, DepartureTime: departure}}, nil)
+			},
+			ExpectedCode:    codes.OK,
+			ExpectedFlights: map[string]string{"A1": "airline_a", "B1": "airline_b"},
+		},
+		{
+			Name:    "Streams flights when a supplier fails",
+			Request: &pb.SearchFlightsRequest{Origin: "LHR", Destination: "JFK", DepartureDate: "2025-03-01"},
+			SetupFakes: func(a, b *domainfakes.FakeFlightsService) {
+				a.GetFlightsReturns(nil, errors.New("internal server error"))
+				b.GetFlightsReturns(domain.DuffelFlights{{FlightNumber: "B1", TotalAmount: 120, DepartureTime: departure}}, nil)
+			},
+			ExpectedCode:    codes.OK,
+			ExpectedFlights: map[string]string{"B1": "airline_b"},
+		},
+		{
+			Name:    "Returns INTERNAL when every supplier fails",
+			Request: &pb.SearchFlightsRequest{Origin: "LHR", Destination: "JFK", DepartureDate: "2025-03-01"},
+			SetupFakes: func(a, b *domainfakes.FakeFlightsService) {
+				a.GetFlightsReturns(nil, errors.New("internal server error"))
+				b.GetFlightsReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedCode:    codes.Internal,
+			ExpectedFlights: map[string]string{},
+		},
+		{
+			Name:            "Returns INVALID_ARGUMENT for an invalid departure date",
+			Request:         &pb.SearchFlightsRequest{Origin: "LHR", Destination: "JFK", DepartureDate: "01/03/2025"},
+			SetupFakes:      func(a, b *domainfakes.FakeFlightsService) {},
+			ExpectedCode:    codes.InvalidArgument,
+			ExpectedFlights: map[string]string{},
+		},
+		{
+			Name:            "Returns INVALID_ARGUMENT for an invalid airport code",
+			Request:         &pb.SearchFlightsRequest{Origin: "LHR", Destination: "NYC-JFK", DepartureDate: "2025-03-01"},
+			SetupFakes:      func(a, b *domainfakes.FakeFlightsService) {},
+			ExpectedCode:    codes.InvalidArgument,
+			ExpectedFlights: map[string]string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			airlineA := new(domainfakes.FakeFlightsService)
+			airlineB := new(domainfakes.FakeFlightsService)
+			tc.SetupFakes(airlineA, airlineB)
+
+			srv := rpc.NewServer(logger, new(domainfakes.FakeMoviesService),
+				rpc.Supplier{Name: "airline_a", Flights: airlineA},
+				rpc.Supplier{Name: "airline_b", Flights: airlineB},
+			)
+			client := pb.NewFlightsServiceClient(dial(t, srv))
+
+			stream, err := client.SearchFlights(context.Background(), tc.Request)
+			assert.NoError(t, err)
+
+			flights := map[string]string{}
+			for {
+				flight, err := stream.Recv()
+				if err != nil {
+					if err != io.EOF {
+						assert.Equal(t, tc.ExpectedCode, status.Code(err))
+					} else {
+						assert.Equal(t, codes.OK, tc.ExpectedCode)
+					}
+					break
+				}
+				flights[flight.GetFlightNumber()] = flight.GetSupplier()
+				assert.True(t, departure.Equal(flight.GetDepartureTime().AsTime()))
+			}
+			assert.Equal(t, tc.ExpectedFlights, flights)
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	srv := rpc.NewServer(logger, new(domainfakes.FakeMoviesService))
+	client := healthpb.NewHealthClient(dial(t, srv))
+
+	for _, service := range []string{"", "simpleapi.v1.MCUService", "simpleapi.v1.FlightsService"} {
+		res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		assert.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	}
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,381 @@
+package search
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/internal/textmatch"
+)
+
+type field int
+
+const (
+	fieldTitle field = iota
+	fieldOverview
+)
+
+var fieldBoost = map[field]float64{
+	fieldTitle:    3,
+	fieldOverview: 1,
+}
+
+// Weights applied to a query token depending on how it matched an indexed
+// term, so exact hits outrank prefix hits, which outrank typo-tolerant ones.
+const (
+	weightExact  = 1.0
+	weightPrefix = 0.7
+	weightFuzzy  = 0.5
+
+	minPrefixLength = 2
+	snippetRadius   = 80
+)
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "at": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true,
+	"on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+type token struct {
+	term       string
+	start, end int
+}
+
+type posting struct {
+	movieID int
+	field   field
+	tf      int
+}
+
+// Index is an in-memory inverted index over movie titles and overviews.
+type Index struct {
+	movies   map[int]*domain.Movie
+	postings map[string][]posting
+	terms    []string
+	lengths  map[int]map[field]int
+	avgLen   map[field]float64
+}
+
+type Result struct {
+	Movie      *domain.Movie     `json:"movie"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+func NewIndex(movies domain.Movies) *Index {
+	idx := &Index{
+		movies:   make(map[int]*domain.Movie, len(movies)),
+		postings: make(map[string][]posting),
+		lengths:  make(map[int]map[field]int, len(movies)),
+		avgLen:   make(map[field]float64),
+	}
+
+	for _, movie := range movies {
+		idx.movies[movie.ID] = movie
+		idx.lengths[movie.ID] = make(map[field]int)
+
+		for f, text := range fieldText(movie) {
+			counts := make(map[string]int)
+			tokens := tokenize(text)
+			for _, tok := range tokens {
+				counts[tok.term]++
+			}
+			for term, tf := range counts {
+				idx.postings[term] = append(idx.postings[term], posting{movieID: movie.ID, field: f, tf: tf})
+			}
+			idx.lengths[movie.ID][f] = len(tokens)
+			idx.avgLen[f] += float64(len(tokens))
+		}
+	}
+
+	for f := range idx.avgLen {
+		idx.avgLen[f] /= math.Max(1, float64(len(movies)))
+	}
+
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	sort.Strings(idx.terms)
+
+	return idx
+}
+
+// Search returns up to limit movies matching query, most relevant first. Each
+// query token matches indexed terms exactly, by prefix, or within a small edit
+// distance.
+func (idx *Index) Search(query string, limit int) []*Result {
+	qtokens := queryTerms(query)
+	if len(qtokens) == 0 {
+		return []*Result{}
+	}
+
+	scores := make(map[int]float64)
+	coverage := make(map[int]int)
+	matched := make(map[int]map[string]bool)
+
+	for _, qt := range qtokens {
+		hit := make(map[int]bool)
+		for term, weight := range idx.expand(qt) {
+			idf := idx.idf(term)
+			for _, p := range idx.postings[term] {
+				scores[p.movieID] += weight * idf * fieldBoost[p.field] * idx.tfNorm(p)
+				hit[p.movieID] = true
+
+				if matched[p.movieID] == nil {
+					matched[p.movieID] = make(map[string]bool)
+				}
+				matched[p.movieID][term] = true
+			}
+		}
+		for id := range hit {
+			coverage[id]++
+		}
+	}
+
+	results := make([]*Result, 0, len(scores))
+	for id, score := range scores {
+		movie := idx.movies[id]
+		results = append(results, &Result{
+			Movie:      movie,
+			Score:      math.Round(score*float64(coverage[id])/float64(len(qtokens))*1000) / 1000,
+			Highlights: highlights(movie, matched[id]),
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Movie.ID < results[j].Movie.ID
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// expand returns the indexed terms a query token matches along with the
+// weight of each match.
+func (idx *Index) expand(qt string) map[string]float64 {
+	matches := make(map[string]float64)
+
+	if _, ok := idx.postings[qt]; ok {
+		matches[qt] = weightExact
+	}
+
+	if len([]rune(qt)) >= minPrefixLength {
+		i := sort.SearchStrings(idx.terms, qt)
+		for ; i < len(idx.terms) && strings.HasPrefix(idx.terms[i], qt); i++ {
+			if _, ok := matches[idx.terms[i]]; !ok {
+				matches[idx.terms[i]] = weightPrefix
+			}
+		}
+	}
+
+	maxDist := maxEdits(qt)
+	if maxDist == 0 {
+		return matches
+	}
+
+	for _, term := range idx.terms {
+		if _, ok := matches[term]; ok {
+			continue
+		}
+		if abs(len(term)-len(qt)) > maxDist {
+			continue
+		}
+		if textmatch.EditDistance(qt, term, maxDist) <= maxDist {
+			matches[term] = weightFuzzy
+		}
+	}
+
+	return matches
+}
+
+func (idx *Index) idf(term string) float64 {
+	docs := make(map[int]bool)
+	for _, p := range idx.postings[term] {
+		docs[p.movieID] = true
+	}
+	n := float64(len(idx.movies))
+	df := float64(len(docs))
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// tfNorm is the BM25 term frequency component with k1 = 1.2 and b = 0.75.
+func (idx *Index) tfNorm(p posting) float64 {
+	const k1, b = 1.2, 0.75
+	tf := float64(p.tf)
+	length := float64(idx.lengths[p.movieID][p.field])
+	avg := math.Max(1, idx.avgLen[p.field])
+	return tf * (k1 + 1) / (tf + k1*(1-b+b*length/avg))
+}
+
+func maxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func fieldText(movie *domain.Movie) map[field]string {
+	return map[field]string{
+		fieldTitle:    movie.Title,
+		fieldOverview: movie.Overview,
+	}
+}
+
+// tokenize splits text into folded terms, recording each term's byte offsets
+// in the original text for highlighting.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		tokens = append(tokens, token{term: textmatch.Fold(text[start:end]), start: start, end: end})
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+
+	return tokens
+}
+
+// Terms splits text into the folded terms it would be indexed under, leaving
+// out stopwords.
+func Terms(text string) []string {
+	var terms []string
+	for _, tok := range tokenize(text) {
+		if !stopwords[tok.term] {
+			terms = append(terms, tok.term)
+		}
+	}
+	return terms
+}
+
+func queryTerms(query string) []string {
+	var all, terms []string
+	seen := make(map[string]bool)
+	for _, tok := range tokenize(query) {
+		if seen[tok.term] {
+			continue
+		}
+		seen[tok.term] = true
+		all = append(all, tok.term)
+		if !stopwords[tok.term] {
+			terms = append(terms, tok.term)
+		}
+	}
+
+	if len(terms) == 0 {
+		return all
+	}
+	return terms
+}
+
+func highlights(movie *domain.Movie, matched map[string]bool) map[string]string {
+	h := make(map[string]string)
+	if snippet, ok := highlight(movie.Title, matched, false); ok {
+		h["title"] = snippet
+	}
+	if snippet, ok := highlight(movie.Overview, matched, true); ok {
+		h["overview"] = snippet
+	}
+	return h
+}
+
+// highlight wraps matched terms in text with <mark> tags, escaping the rest of
+// text as HTML. When truncate is set, only a window of text around the first
+// match is returned.
+func highlight(text string, matched map[string]bool, truncate bool) (string, bool) {
+	var spans []token
+	for _, tok := range tokenize(text) {
+		if matched[tok.term] {
+			spans = append(spans, tok)
+		}
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(text)
+	if truncate {
+		from = wordBoundary(text, spans[0].start-snippetRadius, false)
+		to = wordBoundary(text, spans[0].end+snippetRadius, true)
+	}
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString("…")
+	}
+
+	pos := from
+	for _, span := range spans {
+		if span.start < from || span.end > to {
+			continue
+		}
+		sb.WriteString(html.EscapeString(text[pos:span.start]))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(text[span.start:span.end]))
+		sb.WriteString("</mark>")
+		pos = span.end
+	}
+	sb.WriteString(html.EscapeString(text[pos:to]))
+
+	if to < len(text) {
+		sb.WriteString("…")
+	}
+
+	return strings.TrimSpace(sb.String()), true
+}
+
+// wordBoundary moves i to the nearest space in the given direction, clamped to
+// the bounds of text.
+func wordBoundary(text string, i int, forward bool) int {
+	if i <= 0 {
+		return 0
+	}
+	if i >= len(text) {
+		return len(text)
+	}
+
+	if forward {
+		if j := strings.IndexByte(text[i:], ' '); j >= 0 {
+			return i + j
+		}
+		return len(text)
+	}
+
+	if j := strings.LastIndexByte(text[:i], ' '); j >= 0 {
+		return j + 1
+	}
+	return 0
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,123 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/search"
+)
+
+func TestIndexSearch(t *testing.T) {
+	movies := domain.Movies{
+		{
+			ID:       1,
+			Title:    "Iron Man",
+			Overview: "Tony Stark, a billionaire industrialist and genius inventor, is kidnapped and forced to build a devastating weapon.",
+		},
+		{
+			ID:       9,
+			Title:    "Captain America: The Winter Soldier",
+			Overview: "Steve Rogers struggles to embrace his role in the modern world and battles a new threat from old history: the Soviet agent known as the Winter Soldier.",
+		},
+		{
+			ID:       10,
+			Title:    "Guardians of the Galaxy",
+			Overview: "Brash space adventurer Peter Quill finds himself the object of a manhunt after stealing an orb coveted by Ronan.",
+		},
+		{
+			ID:       15,
+			Title:    "Guardians of the Galaxy Vol. 2",
+			Overview: "The Guardians must fight to keep their newfound family together as they unravel the mysteries of Peter Quill's true parentage.",
+		},
+		{
+			ID:       26,
+			Title:    "Eternals",
+			Overview: "Directed by Chloé Zhao, the saga of the Eternals, a race of immortal beings who lived on Earth.",
+		},
+		{
+			ID:       34,
+			Title:    "Deadpool & Wolverine",
+			Overview: "Deadpool <script>alert('x')</script> teams up with Wolverine.",
+		},
+	}
+
+	idx := search.NewIndex(movies)
+
+	tt := []struct {
+		Name               string
+		Query              string
+		Limit              int
+		ExpectedIDs        []int
+		ExpectedHighlights map[string]string
+	}{
+		{
+			Name:        "Matches exact terms and ranks by relevance",
+			Query:       "guardians",
+			ExpectedIDs: []int{15, 10},
+			ExpectedHighlights: map[string]string{
+				"title":    "<mark>Guardians</mark> of the Galaxy Vol. 2",
+				"overview": "The <mark>Guardians</mark> must fight to keep their newfound family together as they unravel the mysteries…",
+			},
+		},
+		{
+			Name:        "Tolerates typos",
+			Query:       "winter soldjer",
+			ExpectedIDs: []int{9},
+			ExpectedHighlights: map[string]string{
+				"title":    "Captain America: The <mark>Winter</mark> <mark>Soldier</mark>",
+				"overview": "…modern world and battles a new threat from old history: the Soviet agent known as the <mark>Winter</mark> <mark>Soldier</mark>.",
+			},
+		},
+		{
+			Name:        "Matches prefixes case-insensitively",
+			Query:       "GALA",
+			ExpectedIDs: []int{10, 15},
+		},
+		{
+			Name:        "Folds accents",
+			Query:       "chloe",
+			ExpectedIDs: []int{26},
+			ExpectedHighlights: map[string]string{
+				"overview": "Directed by <mark>Chloé</mark> Zhao, the saga of the Eternals, a race of immortal beings who lived on Earth.",
+			},
+		},
+		{
+			Name:        "Escapes HTML around highlights",
+			Query:       "deadpool",
+			ExpectedIDs: []int{34},
+			ExpectedHighlights: map[string]string{
+				"title":    "<mark>Deadpool</mark> &amp; Wolverine",
+				"overview": "<mark>Deadpool</mark> &lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt; teams up with Wolverine.",
+			},
+		},
+		{
+			Name:        "Applies limit",
+			Query:       "peter quill",
+			Limit:       1,
+			ExpectedIDs: []int{10},
+		},
+		{
+			Name:        "Returns no results when nothing matches",
+			Query:       "thanos",
+			ExpectedIDs: []int{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			results := idx.Search(tc.Query, tc.Limit)
+
+			ids := []int{}
+			for _, res := range results {
+				ids = append(ids, res.Movie.ID)
+			}
+			assert.Equal(t, tc.ExpectedIDs, ids)
+
+			if tc.ExpectedHighlights != nil {
+				assert.Equal(t, tc.ExpectedHighlights, results[0].Highlights)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const formatCSV = "csv"
+
+var csvFormats = map[string]string{formatCSV: "text/csv"}
+
+// respondCSV writes rows as a CSV download named filename, with header as the
+// first row.
+func respondCSV(w http.ResponseWriter, filename string, header []string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	for _, row := range rows {
+		cw.Write(csvRow(row))
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		slog.Error("error writing csv", slog.Any("error", err))
+	}
+}
+
+// csvRow neutralises cells that spreadsheets would otherwise evaluate as
+// formulas by prefixing them with a single quote. Numbers, including negative
+// ones, are left as they are.
+func csvRow(row []string) []string {
+	safe := make([]string, len(row))
+	for i, cell := range row {
+		safe[i] = cell
+		if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			continue
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err == nil {
+			continue
+		}
+		safe[i] = "'" + cell
+	}
+	return safe
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+func ftoa(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
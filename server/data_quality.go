@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type DataQualityHandler struct {
+	quality domain.DataQualityService
+}
+
+func NewDataQualityHandler(quality domain.DataQualityService) *DataQualityHandler {
+	return &DataQualityHandler{
+		quality: quality,
+	}
+}
+
+func (h *DataQualityHandler) RegisterRoutes(r *mux.Router) {
+	r.Handle("/data-quality", handlerFunc(h.GetDataQuality)).Methods(http.MethodGet).Name("getDataQuality")
+}
+
+// GetDataQuality reports the problems found in the upstream MCU data, such
+// as fields that had to be defaulted and records that were skipped.
+func (h *DataQualityHandler) GetDataQuality(w http.ResponseWriter, r *http.Request) error {
+	report, err := h.quality.GetDataQuality(r.Context())
+	if err != nil {
+		return err
+	}
+
+	setResultCount(r, len(report.Issues))
+	respondJSON(w, http.StatusOK, report)
+	return nil
+}
@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestGetDataQuality(t *testing.T) {
+	report := &domain.DataQualityReport{
+		CheckedAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		TotalRecords:   2,
+		SkippedRecords: 1,
+		Issues: []*domain.DataQualityIssue{
+			{Record: 0, MovieID: 1, Field: "box_office", Problem: domain.DataQualityMissing, Value: "TBA"},
+			{Record: 1, Field: "id", Problem: domain.DataQualityInvalid, Skipped: true},
+		},
+	}
+
+	tt := []struct {
+		Name           string
+		SetupFake      func(fake *domainfakes.FakeDataQualityService)
+		ExpectedStatus int
+		ExpectedBody   *domain.DataQualityReport
+	}{
+		{
+			Name: "Returns status 200 with the data-quality report",
+			SetupFake: func(fake *domainfakes.FakeDataQualityService) {
+				fake.GetDataQualityReturns(report, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   report,
+		},
+		{
+			Name: "Returns status 500 when service request fails",
+			SetupFake: func(fake *domainfakes.FakeDataQualityService) {
+				fake.GetDataQualityReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeDataQualityService)
+			tc.SetupFake(service)
+
+			router := mux.NewRouter()
+			handler := server.NewDataQualityHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", "/data-quality", nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if tc.ExpectedBody != nil {
+				var res domain.DataQualityReport
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, tc.ExpectedBody, &res)
+			}
+		})
+	}
+}
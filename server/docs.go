@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+)
+
+type DocsHandler struct {
+	doc *openapi3.T
+}
+
+func NewDocsHandler(doc *openapi3.T) *DocsHandler {
+	return &DocsHandler{
+		doc: doc,
+	}
+}
+
+func (h *DocsHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/openapi.json", h.GetOpenAPI).Methods(http.MethodGet)
+	r.HandleFunc("/docs", h.GetDocs).Methods(http.MethodGet)
+}
+
+func (h *DocsHandler) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, h.doc)
+}
+
+// docsPage renders /openapi.json with Swagger UI.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Simple API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+func (h *DocsHandler) GetDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(docsPage))
+}
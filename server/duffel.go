@@ -1,14 +1,17 @@
 package server
 
 import (
-	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/logging"
 )
 
 type DuffelFlightsHandler struct {
@@ -24,56 +27,55 @@ func NewDuffelFlightsHandler(airlineA, airlineB domain.FlightsService) *DuffelFl
 }
 
 func (h *DuffelFlightsHandler) RegisterRoutes(r *mux.Router) {
-	r.HandleFunc("/flights/search", h.SearchFlights).Methods(http.MethodPost)
+	r.Handle("/flights/search", handlerFunc(h.SearchFlights)).Methods(http.MethodPost).Name("searchFlights")
 }
 
+var errNoFlights = errors.New("no flights from any airline")
+
 type SearchFlightsRequest struct {
 	Origin        string `json:"origin"`
 	Destination   string `json:"destination"`
 	DepartureDate string `json:"departure_date"`
 }
 
-func (h *DuffelFlightsHandler) SearchFlights(w http.ResponseWriter, r *http.Request) {
+func (h *DuffelFlightsHandler) SearchFlights(w http.ResponseWriter, r *http.Request) error {
 	body := &SearchFlightsRequest{}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
-		return
+	if err := decodeJSON(r, body); err != nil {
+		return err
 	}
 
 	departureDate, err := time.Parse("2006-01-02", body.DepartureDate)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid departure date, must be of format YYYY-MM-DD")
-		return
+		return invalidBody("departure_date", "Invalid departure date, must be of format YYYY-MM-DD")
 	}
 
 	switch {
 	case len(body.Origin) > 3:
-		respondError(w, http.StatusBadRequest, "Invalid airport code for origin")
-		return
+		return invalidBody("origin", "Invalid airport code for origin")
 	case len(body.Destination) > 3:
-		respondError(w, http.StatusBadRequest, "Invalid airport code for destination")
-		return
+		return invalidBody("destination", "Invalid airport code for destination")
 	}
 
 	flights := domain.DuffelFlights{}
 
-	flightsA, err := h.airlineA.GetFlights(r.Context(), body.Origin, body.Destination, departureDate.Format("2006-01-02"))
-	if err != nil {
-		log.Printf("GetFlights request error: %s [airline = A]\n", err)
+	flightsA, errA := h.airlineA.GetFlights(r.Context(), body.Origin, body.Destination, departureDate.Format("2006-01-02"))
+	if errA != nil {
+		logging.FromContext(r.Context()).Error("GetFlights request error", slog.Any("error", errA), slog.String("supplier", "airline_a"))
+		trace.SpanFromContext(r.Context()).RecordError(errA, trace.WithAttributes(attribute.String("supplier", "airline_a")))
 	} else {
 		flights = append(flights, flightsA...)
 	}
 
-	flightsB, err := h.airlineB.GetFlights(r.Context(), body.Origin, body.Destination, departureDate.Format("2006-01-02"))
-	if err != nil {
-		log.Printf("GetFlights request error: %s [airline = B]\n", err)
+	flightsB, errB := h.airlineB.GetFlights(r.Context(), body.Origin, body.Destination, departureDate.Format("2006-01-02"))
+	if errB != nil {
+		logging.FromContext(r.Context()).Error("GetFlights request error", slog.Any("error", errB), slog.String("supplier", "airline_b"))
+		trace.SpanFromContext(r.Context()).RecordError(errB, trace.WithAttributes(attribute.String("supplier", "airline_b")))
 	} else {
 		flights = append(flights, flightsB...)
 	}
 
 	if len(flights) == 0 {
-		respondError(w, http.StatusInternalServerError, "Internal server error")
-		return
+		return errors.Join(errNoFlights, errA, errB)
 	}
 
 	sortBy := r.URL.Query().Get("sort_by")
@@ -86,5 +88,7 @@ func (h *DuffelFlightsHandler) SearchFlights(w http.ResponseWriter, r *http.Requ
 		flights = flights.SortByDuration(domain.SortOrder(sortOrder))
 	}
 
+	setResultCount(r, len(flights))
 	respondJSON(w, http.StatusOK, flights)
+	return nil
 }
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+// movieFields are the fields of domain.Movie that can be selected with the
+// "fields" query parameter.
+var movieFields = jsonFieldNames(domain.Movie{})
+
+func jsonFieldNames(v interface{}) map[string]bool {
+	names := make(map[string]bool)
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// parseFields parses a comma-separated "fields" query parameter, returning nil
+// when it is absent.
+func parseFields(r *http.Request, allowed map[string]bool) ([]string, error) {
+	param := r.URL.Query().Get("fields")
+	if param == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !allowed[field] {
+			return nil, invalidParam("fields", "Invalid field %q", field)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// sparse marshals v, an object or an array of objects, keeping only the given
+// fields. All fields are kept when none are given.
+type sparse struct {
+	v      interface{}
+	fields []string
+}
+
+func (s sparse) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(s.v)
+	if err != nil || len(s.fields) == 0 {
+		return data, err
+	}
+
+	if len(data) > 0 && data[0] == '[' {
+		var objs []map[string]json.RawMessage
+		if err := json.Unmarshal(data, &objs); err != nil {
+			return nil, err
+		}
+		for i, obj := range objs {
+			objs[i] = pick(obj, s.fields)
+		}
+		return json.Marshal(objs)
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return json.Marshal(pick(obj, s.fields))
+}
+
+func pick(obj map[string]json.RawMessage, fields []string) map[string]json.RawMessage {
+	picked := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if v, ok := obj[field]; ok {
+			picked[field] = v
+		}
+	}
+	return picked
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const formatJSON = "json"
+
+// negotiateFormat picks the response format from the format query parameter,
+// falling back to the Accept header. formats maps each format offered besides
+// JSON to its media type.
+func negotiateFormat(r *http.Request, formats map[string]string) (string, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		if _, ok := formats[format]; format != formatJSON && !ok {
+			names := []string{formatJSON}
+			for name := range formats {
+				names = append(names, name)
+			}
+			sort.Strings(names[1:])
+			return "", invalidParam("format", "Invalid format, must be one of %s", strings.Join(names, ", "))
+		}
+		return format, nil
+	}
+
+	accept := r.Header.Get("Accept")
+	for format, mediaType := range formats {
+		if strings.Contains(accept, mediaType) {
+			return format, nil
+		}
+	}
+	return formatJSON, nil
+}
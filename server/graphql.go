@@ -0,0 +1,53 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/graph"
+)
+
+type GraphQLHandler struct {
+	schema *graph.Schema
+}
+
+func NewGraphQLHandler(schema *graph.Schema) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: schema,
+	}
+}
+
+func (h *GraphQLHandler) RegisterRoutes(r *mux.Router) {
+	r.Handle("/graphql", handlerFunc(h.Query)).Methods(http.MethodGet, http.MethodPost).Name("graphql")
+}
+
+// Query executes a GraphQL request, given as a JSON body or, for GET
+// requests, as query parameters. Errors executing the query are reported in
+// the response body with status 200, as is conventional for GraphQL.
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) error {
+	req := graph.Request{}
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return invalidParam("variables", "Invalid variables, must be a JSON object")
+			}
+		}
+	default:
+		if err := decodeJSON(r, &req); err != nil {
+			return err
+		}
+	}
+
+	if req.Query == "" {
+		return invalidRequest("Missing query")
+	}
+
+	respondJSON(w, http.StatusOK, h.schema.Execute(r.Context(), req))
+	return nil
+}
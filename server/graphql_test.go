@@ -0,0 +1,101 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/graph"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestGraphQL(t *testing.T) {
+	movies := domain.Movies{
+		{ID: 1, Title: "Iron Man", Phase: 1, Saga: "Infinity Saga"},
+		{ID: 2, Title: "Black Widow", Phase: 4, Saga: "Multiverse Saga"},
+	}
+
+	tt := []struct {
+		Name           string
+		Method         string
+		Target         string
+		Body           string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		{
+			Name:           "Returns status 200 with the result of a POST query",
+			Method:         "POST",
+			Target:         "/graphql",
+			Body:           `{"query": "query($phase: Int) { movies(phase: $phase) { title } }", "variables": {"phase": 4}}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"data": {"movies": [{"title": "Black Widow"}]}}`,
+		},
+		{
+			Name:           "Returns status 200 with the result of a GET query",
+			Method:         "GET",
+			Target:         "/graphql?" + url.Values{"query": {"{ movies { id } }"}}.Encode(),
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"data": {"movies": [{"id": 1}, {"id": 2}]}}`,
+		},
+		{
+			Name:           "Returns status 200 with errors for an invalid query",
+			Method:         "POST",
+			Target:         "/graphql",
+			Body:           `{"query": "{ movies { budget } }"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"data": null, "errors": [{"message": "Cannot query field \"budget\" on type \"Movie\".", "locations": [{"line": 1, "column": 12}]}]}`,
+		},
+		{
+			Name:           "Returns status 400 for a missing query",
+			Method:         "POST",
+			Target:         "/graphql",
+			Body:           `{}`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `{"type": "urn:simple-api:problem:invalid_request", "title": "Invalid request", "status": 400, "detail": "Missing query", "instance": "/graphql", "code": "invalid_request"}`,
+		},
+		{
+			Name:           "Returns status 400 for invalid variables",
+			Method:         "GET",
+			Target:         "/graphql?" + url.Values{"query": {"{ movies { id } }"}, "variables": {"phase"}}.Encode(),
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody: `{"type": "urn:simple-api:problem:invalid_request", "title": "Invalid request", "status": 400, "detail": "Invalid variables, must be a JSON object", "instance": "/graphql", "code": "invalid_request", "issues": [
+				{"in": "query", "name": "variables", "message": "Invalid variables, must be a JSON object"}
+			]}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMoviesReturns(movies, nil)
+
+			schema, err := graph.NewSchema(service, nil, graph.DefaultLimits())
+			assert.NoError(t, err)
+
+			router := mux.NewRouter()
+			handler := server.NewGraphQLHandler(schema)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest(tc.Method, tc.Target, strings.NewReader(tc.Body))
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			var body json.RawMessage
+			assert.NoError(t, json.NewDecoder(rw.Body).Decode(&body))
+			assert.JSONEq(t, tc.ExpectedBody, string(body))
+		})
+	}
+}
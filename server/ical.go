@@ -0,0 +1,71 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+const icalTimeFormat = "20060102T150405Z"
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// respondICal writes the marathon as an iCalendar (RFC 5545) file with one
+// event per movie.
+func respondICal(w http.ResponseWriter, marathon *domain.Marathon) {
+	var sb strings.Builder
+	line := func(format string, args ...interface{}) {
+		sb.WriteString(foldICalLine(fmt.Sprintf(format, args...)))
+		sb.WriteString("\r\n")
+	}
+
+	stamp := time.Now().UTC().Format(icalTimeFormat)
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//jace-ys//simple-api//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:MCU Marathon")
+
+	for _, day := range marathon.Days {
+		for _, session := range day.Sessions {
+			line("BEGIN:VEVENT")
+			line("UID:mcu-marathon-%d-%d@simple-api", session.Movie.ID, session.Start.Unix())
+			line("DTSTAMP:%s", stamp)
+			line("DTSTART:%s", session.Start.UTC().Format(icalTimeFormat))
+			line("DTEND:%s", session.End.UTC().Format(icalTimeFormat))
+			line("SUMMARY:%s", icalEscaper.Replace(session.Movie.Title))
+			line("DESCRIPTION:%s", icalEscaper.Replace(fmt.Sprintf("Day %d of your MCU marathon.\n\n%s", day.Day, session.Movie.Overview)))
+			line("END:VEVENT")
+		}
+	}
+
+	line("END:VCALENDAR")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="mcu-marathon.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(sb.String()))
+}
+
+// foldICalLine splits lines longer than 75 octets as required by RFC 5545,
+// without breaking up multi-byte characters.
+func foldICalLine(s string) string {
+	const limit = 75
+
+	var sb strings.Builder
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > limit {
+			sb.WriteString("\r\n ")
+			n = 1
+		}
+		sb.WriteRune(r)
+		n += size
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/logging"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// LogRequests wraps router so that every request carries a logger annotated
+// with its request ID and route, and emits a JSON access log record once the
+// response has been written. A request ID sent by the caller is reused.
+func LogRequests(logger *slog.Logger, router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" {
+			requestID = logging.NewRequestID()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+
+		route := "unmatched"
+		var match mux.RouteMatch
+		if router.Match(r, &match) && match.Route != nil {
+			if tmpl, err := match.Route.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+
+		reqLogger := logger.With(
+			slog.String("request_id", requestID),
+			slog.String("route", route),
+		)
+		ctx := logging.WithRequestID(r.Context(), requestID)
+		r = r.WithContext(logging.WithLogger(ctx, reqLogger))
+
+		m := httpsnoop.CaptureMetrics(router, w, r)
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("query", r.URL.RawQuery),
+			slog.Int("status", m.Code),
+			slog.Int64("bytes", m.Written),
+			slog.Duration("duration", m.Duration),
+			slog.String("remote_addr", r.RemoteAddr),
+			slog.String("user_agent", r.UserAgent()),
+		}
+		reqLogger.LogAttrs(r.Context(), slog.LevelInfo, "request handled", attrs...)
+	})
+}
@@ -0,0 +1,102 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/logging"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestLogRequests(t *testing.T) {
+	tt := []struct {
+		Name              string
+		Endpoint          string
+		RequestID         string
+		ExpectedStatus    int
+		ExpectedRoute     string
+		ExpectedRecords   int
+		ExpectedRequestID string
+	}{
+		{
+			Name:            "Logs error and access records with request fields",
+			Endpoint:        "/movies/4",
+			ExpectedStatus:  http.StatusNotFound,
+			ExpectedRoute:   "/movies/{id}",
+			ExpectedRecords: 2,
+		},
+		{
+			Name:              "Reuses request ID sent by caller",
+			Endpoint:          "/movies/4",
+			RequestID:         "abc123",
+			ExpectedStatus:    http.StatusNotFound,
+			ExpectedRoute:     "/movies/{id}",
+			ExpectedRecords:   2,
+			ExpectedRequestID: "abc123",
+		},
+		{
+			Name:            "Logs unmatched routes",
+			Endpoint:        "/unknown",
+			ExpectedStatus:  http.StatusNotFound,
+			ExpectedRoute:   "unmatched",
+			ExpectedRecords: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMovieReturns(nil, domain.ErrMovieNotFound)
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			var buf bytes.Buffer
+			logger := logging.New(&buf, slog.LevelDebug)
+
+			req, err := http.NewRequest("GET", tc.Endpoint, nil)
+			assert.NoError(t, err)
+			if tc.RequestID != "" {
+				req.Header.Set("X-Request-ID", tc.RequestID)
+			}
+
+			rw := httptest.NewRecorder()
+			server.LogRequests(logger, router).ServeHTTP(rw, req)
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			requestID := rw.Header().Get("X-Request-ID")
+			assert.NotEmpty(t, requestID)
+			if tc.ExpectedRequestID != "" {
+				assert.Equal(t, tc.ExpectedRequestID, requestID)
+			}
+
+			var records []map[string]interface{}
+			dec := json.NewDecoder(&buf)
+			for dec.More() {
+				var record map[string]interface{}
+				assert.NoError(t, dec.Decode(&record))
+				records = append(records, record)
+			}
+
+			assert.Len(t, records, tc.ExpectedRecords)
+			for _, record := range records {
+				assert.Equal(t, requestID, record["request_id"])
+				assert.Equal(t, tc.ExpectedRoute, record["route"])
+			}
+
+			access := records[len(records)-1]
+			assert.Equal(t, "request handled", access["msg"])
+			assert.Equal(t, float64(tc.ExpectedStatus), access["status"])
+		})
+	}
+}
@@ -1,19 +1,30 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/logging"
+	"github.com/jace-ys/simple-api/search"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
 )
 
 type MCUHandler struct {
-	movies domain.MoviesService
+	movies  domain.MoviesService
+	ratings domain.RatingsService
+	index   snapshotCache
 }
 
 func NewMCUHandler(movies domain.MoviesService) *MCUHandler {
@@ -22,105 +33,277 @@ func NewMCUHandler(movies domain.MoviesService) *MCUHandler {
 	}
 }
 
+// WithRatings includes user ratings in movie responses.
+func (h *MCUHandler) WithRatings(ratings domain.RatingsService) *MCUHandler {
+	h.ratings = ratings
+	return h
+}
+
+// setFreshness reports the age of the snapshot being served, if any.
+func (h *MCUHandler) setFreshness(w http.ResponseWriter) {
+	s, ok := h.movies.(snapshotter)
+	if !ok {
+		return
+	}
+
+	loadedAt := s.LoadedAt()
+	if loadedAt.IsZero() {
+		return
+	}
+
+	w.Header().Set("Age", strconv.Itoa(int(time.Since(loadedAt).Seconds())))
+	w.Header().Set("Last-Modified", loadedAt.UTC().Format(http.TimeFormat))
+}
+
 func (h *MCUHandler) RegisterRoutes(r *mux.Router) {
-	r.HandleFunc("/movies", h.GetMovies).Methods(http.MethodGet)
-	r.HandleFunc("/movies/{id}", h.GetMovie)
-	r.HandleFunc("/sagas", h.GetSagas).Methods(http.MethodGet)
+	r.Handle("/movies", handlerFunc(h.GetMovies)).Methods(http.MethodGet).Name("listMovies")
+	r.Handle("/movies/search", handlerFunc(h.SearchMovies)).Methods(http.MethodGet).Name("searchMovies")
+	r.Handle("/movies/{id}", handlerFunc(h.GetMovie)).Name("getMovie")
+	r.Handle("/sagas", handlerFunc(h.GetSagas)).Methods(http.MethodGet).Name("listSagas")
+	r.Handle("/sagas/{saga}", handlerFunc(h.GetSaga)).Methods(http.MethodGet).Name("getSaga")
+	r.Handle("/sagas/{saga}/phases", handlerFunc(h.GetSagaPhases)).Methods(http.MethodGet).Name("listSagaPhases")
+	r.Handle("/sagas/{saga}/phases/{number}", handlerFunc(h.GetSagaPhase)).Methods(http.MethodGet).Name("getSagaPhase")
+	r.Handle("/stats", handlerFunc(h.GetStats)).Methods(http.MethodGet).Name("getStats")
+	r.Handle("/stats/box-office/by-year", handlerFunc(h.GetBoxOfficeByYear)).Methods(http.MethodGet).Name("getBoxOfficeByYear")
+	r.Handle("/stats/box-office/cumulative", handlerFunc(h.GetCumulativeBoxOffice)).Methods(http.MethodGet).Name("getCumulativeBoxOffice")
+	r.Handle("/stats/box-office/per-minute", handlerFunc(h.GetBoxOfficePerMinute)).Methods(http.MethodGet).Name("getBoxOfficePerMinute")
+	r.Handle("/stats/box-office/top-by-phase", handlerFunc(h.GetTopGrossingByPhase)).Methods(http.MethodGet).Name("getTopGrossingByPhase")
+	r.Handle("/stats/sagas/year-over-year", handlerFunc(h.GetSagaYearOverYear)).Methods(http.MethodGet).Name("getSagaYearOverYear")
+	r.Handle("/watch-order", handlerFunc(h.GetWatchOrder)).Methods(http.MethodGet).Name("getWatchOrder")
+	r.Handle("/watch-order/marathon", handlerFunc(h.PlanMarathon)).Methods(http.MethodGet).Name("planMarathon")
+	r.Handle("/upcoming", handlerFunc(h.GetUpcoming)).Methods(http.MethodGet).Name("listUpcoming")
 }
 
-func (h *MCUHandler) GetMovies(w http.ResponseWriter, r *http.Request) {
+func (h *MCUHandler) GetMovies(w http.ResponseWriter, r *http.Request) error {
+	fields, err := parseFields(r, movieFields)
+	if err != nil {
+		return err
+	}
+
+	query, err := parseMoviesQuery(r)
+	if err != nil {
+		return err
+	}
+
 	movies, err := h.movies.GetMovies(r.Context())
 	if err != nil {
-		log.Printf("GetMovies request error: %s\n", err)
-		switch {
-		default:
-			respondError(w, http.StatusInternalServerError, "Internal server error")
-		}
-		return
+		return err
 	}
 
-	respondJSON(w, http.StatusOK, movies)
+	movies, total := query.apply(h.rate(r.Context(), movies))
+
+	setResultCount(r, len(movies))
+	h.setFreshness(w)
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	respondJSON(w, http.StatusOK, sparse{movies, fields})
+	return nil
 }
 
-func (h *MCUHandler) GetMovie(w http.ResponseWriter, r *http.Request) {
-	id, ok := mux.Vars(r)["id"]
-	if !ok {
-		respondError(w, http.StatusBadRequest, "Missing ID for movie")
-		return
+func (h *MCUHandler) GetMovie(w http.ResponseWriter, r *http.Request) error {
+	movieID, err := movieIDFromPath(r)
+	if err != nil {
+		return err
 	}
 
-	movieID, err := strconv.Atoi(id)
+	fields, err := parseFields(r, movieFields)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid movie ID")
-		return
+		return err
 	}
 
 	movie, err := h.movies.GetMovie(r.Context(), movieID)
 	if err != nil {
-		log.Printf("GetMovie request error: %s [id = %d]\n", err, movieID)
-		switch {
-		case errors.Is(err, domain.ErrMovieNotFound):
-			respondError(w, http.StatusNotFound, "Movie not found")
-		default:
-			respondError(w, http.StatusInternalServerError, "Internal server error")
+		return err
+	}
+
+	movie = h.rate(r.Context(), domain.Movies{movie})[0]
+
+	h.setFreshness(w)
+	respondJSON(w, http.StatusOK, sparse{movie, fields})
+	return nil
+}
+
+func (h *MCUHandler) SearchMovies(w http.ResponseWriter, r *http.Request) error {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		return invalidParam("q", "Missing search query")
+	}
+
+	limit := defaultSearchLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxSearchLimit {
+			return invalidParam("limit", "Invalid limit, must be between 1 and %d", maxSearchLimit)
 		}
-		return
+		limit = n
+	}
+
+	index, err := h.searchIndex(r.Context())
+	if err != nil {
+		return err
 	}
 
-	respondJSON(w, http.StatusOK, movie)
+	results := index.Search(query, limit)
+
+	setResultCount(r, len(results))
+	h.setFreshness(w)
+	respondJSON(w, http.StatusOK, results)
+	return nil
+}
+
+// rate returns copies of movies with their user ratings set. Movies are
+// returned unrated if ratings can't be fetched.
+func (h *MCUHandler) rate(ctx context.Context, movies domain.Movies) domain.Movies {
+	if h.ratings == nil {
+		return movies
+	}
+
+	ratings, err := h.ratings.GetRatings(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Warn("GetRatings request error", slog.Any("error", err))
+		return movies
+	}
+	return movies.WithRatings(ratings)
+}
+
+// searchIndex returns an index over the current movies. When movies are
+// served from a snapshot the index is reused until the snapshot changes.
+func (h *MCUHandler) searchIndex(ctx context.Context) (*search.Index, error) {
+	index, err := h.index.get(ctx, h.movies, func(movies domain.Movies) interface{} {
+		return search.NewIndex(movies)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return index.(*search.Index), nil
 }
 
-func (h *MCUHandler) GetSagas(w http.ResponseWriter, r *http.Request) {
+func (h *MCUHandler) GetSagas(w http.ResponseWriter, r *http.Request) error {
 	name := r.URL.Query().Get("name")
 
 	movies, err := h.movies.GetMovies(r.Context())
 	if err != nil {
-		log.Printf("GetMovies request error: %s\n", err)
-		switch {
-		default:
-			respondError(w, http.StatusInternalServerError, "Internal server error")
-		}
-		return
+		return err
 	}
 
 	if name == "" {
-		respondJSON(w, http.StatusOK, movies.GroupBySaga())
-		return
+		sagas := movies.GroupBySaga()
+		setResultCount(r, len(sagas))
+		h.setFreshness(w)
+		respondJSON(w, http.StatusOK, sagas)
+		return nil
 	}
 
 	saga, err := movies.GetSaga(name)
 	if err != nil {
-		log.Printf("get saga error: %s\n", err)
-		switch {
-		case errors.Is(err, domain.ErrSagaNotFound):
-			respondError(w, http.StatusNotFound, "Saga not found")
-		default:
-			respondError(w, http.StatusInternalServerError, "Internal server error")
-		}
-		return
+		return err
 	}
 
+	h.setFreshness(w)
 	respondJSON(w, http.StatusOK, saga)
+	return nil
 }
 
-func respondError(w http.ResponseWriter, statusCode int, errMsg string) {
-	respondJSON(w, statusCode, map[string]interface{}{
-		"error": map[string]interface{}{
-			"status":  statusCode,
-			"message": errMsg,
-		},
-	})
+func (h *MCUHandler) GetSaga(w http.ResponseWriter, r *http.Request) error {
+	saga, err := h.sagaFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	h.setFreshness(w)
+	respondJSON(w, http.StatusOK, saga)
+	return nil
+}
+
+func (h *MCUHandler) GetSagaPhases(w http.ResponseWriter, r *http.Request) error {
+	saga, err := h.sagaFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	setResultCount(r, len(saga.Phases))
+	h.setFreshness(w)
+	respondJSON(w, http.StatusOK, saga.Phases)
+	return nil
+}
+
+func (h *MCUHandler) GetSagaPhase(w http.ResponseWriter, r *http.Request) error {
+	number, err := strconv.Atoi(mux.Vars(r)["number"])
+	if err != nil {
+		return invalidPathParam("number", "Invalid phase number")
+	}
+
+	saga, err := h.sagaFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	phase, err := saga.GetPhase(number)
+	if err != nil {
+		return err
+	}
+
+	h.setFreshness(w)
+	respondJSON(w, http.StatusOK, phase)
+	return nil
+}
+
+// sagaFromPath looks up the saga named in the request path.
+func (h *MCUHandler) sagaFromPath(r *http.Request) (*domain.Saga, error) {
+	movies, err := h.movies.GetMovies(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return movies.GetSaga(mux.Vars(r)["saga"])
+}
+
+// movieIDFromPath parses the movie ID in the request path.
+func movieIDFromPath(r *http.Request) (int, error) {
+	movieID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		return 0, invalidPathParam("id", "Invalid movie ID")
+	}
+	return movieID, nil
+}
+
+// knownMovieFromPath parses the movie ID in the request path and checks that
+// it refers to a known movie.
+func knownMovieFromPath(r *http.Request, movies domain.MoviesService) (int, error) {
+	movieID, err := movieIDFromPath(r)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := movies.GetMovie(r.Context(), movieID); err != nil {
+		return 0, err
+	}
+
+	return movieID, nil
+}
+
+// decodeJSON decodes the JSON request body into v.
+func decodeJSON(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return invalidBody("", "Invalid request body: %s", err)
+	}
+	return nil
 }
 
 func respondJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
+	respondJSONAs(w, "application/json", statusCode, payload)
+}
+
+// respondJSONAs responds with payload encoded as JSON, under the given
+// JSON-based media type.
+func respondJSONAs(w http.ResponseWriter, contentType string, statusCode int, payload interface{}) {
 	data, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("error marshalling payload: %s\n", err)
+		slog.Error("error marshalling payload", slog.Any("error", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(statusCode)
 	w.Write([]byte(data))
 }
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type ChangesHandler struct {
+	feed domain.ChangeFeed
+}
+
+func NewChangesHandler(feed domain.ChangeFeed) *ChangesHandler {
+	return &ChangesHandler{
+		feed: feed,
+	}
+}
+
+func (h *ChangesHandler) RegisterRoutes(r *mux.Router) {
+	r.Handle("/changes", handlerFunc(h.GetChanges)).Methods(http.MethodGet).Name("getChanges")
+}
+
+// GetChanges returns the catalog changesets after the version given by since,
+// or every retained changeset when since is omitted.
+func (h *ChangesHandler) GetChanges(w http.ResponseWriter, r *http.Request) error {
+	var since int
+	if v := r.URL.Query().Get("since"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return invalidParam("since", "Invalid since, must be a catalog version")
+		}
+		since = n
+	}
+
+	changes, err := h.feed.GetChanges(r.Context(), since)
+	if err != nil {
+		return err
+	}
+
+	setResultCount(r, len(changes.Changesets))
+	respondJSON(w, http.StatusOK, changes)
+	return nil
+}
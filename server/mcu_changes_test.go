@@ -0,0 +1,109 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestGetChanges(t *testing.T) {
+	changes := &domain.CatalogChanges{
+		Version: 3,
+		Changesets: []*domain.Changeset{
+			{
+				Version: 3,
+				Changes: []*domain.MovieChange{{Type: domain.ChangeAdded, MovieID: 3, Title: "Iron Man 2"}},
+			},
+		},
+	}
+
+	tt := []struct {
+		Name           string
+		QueryParams    string
+		SetupFake      func(fake *domainfakes.FakeChangeFeed)
+		ExpectedSince  int
+		ExpectedStatus int
+		ExpectedBody   *domain.CatalogChanges
+	}{
+		{
+			Name:        "Returns status 200 with changes since a version",
+			QueryParams: "?since=2",
+			SetupFake: func(fake *domainfakes.FakeChangeFeed) {
+				fake.GetChangesReturns(changes, nil)
+			},
+			ExpectedSince:  2,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   changes,
+		},
+		{
+			Name: "Returns status 200 with all changes without since",
+			SetupFake: func(fake *domainfakes.FakeChangeFeed) {
+				fake.GetChangesReturns(changes, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   changes,
+		},
+		{
+			Name:           "Returns status 400 when since is invalid",
+			QueryParams:    "?since=yesterday",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:        "Returns status 410 when changes have expired",
+			QueryParams: "?since=1",
+			SetupFake: func(fake *domainfakes.FakeChangeFeed) {
+				fake.GetChangesReturns(nil, domain.ErrChangesExpired)
+			},
+			ExpectedSince:  1,
+			ExpectedStatus: http.StatusGone,
+		},
+		{
+			Name: "Returns status 500 when feed request fails",
+			SetupFake: func(fake *domainfakes.FakeChangeFeed) {
+				fake.GetChangesReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			feed := new(domainfakes.FakeChangeFeed)
+			if tc.SetupFake != nil {
+				tc.SetupFake(feed)
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewChangesHandler(feed)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", "/changes"+tc.QueryParams, nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if feed.GetChangesCallCount() > 0 {
+				_, since := feed.GetChangesArgsForCall(0)
+				assert.Equal(t, tc.ExpectedSince, since)
+			}
+
+			if tc.ExpectedBody != nil {
+				var res domain.CatalogChanges
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, tc.ExpectedBody, &res)
+			}
+		})
+	}
+}
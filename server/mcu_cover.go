@@ -0,0 +1,72 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+const (
+	coverMaxAge       = 24 * time.Hour
+	placeholderMaxAge = 5 * time.Minute
+)
+
+type CoversHandler struct {
+	movies domain.MoviesService
+	covers domain.CoversService
+}
+
+func NewCoversHandler(movies domain.MoviesService, covers domain.CoversService) *CoversHandler {
+	return &CoversHandler{
+		movies: movies,
+		covers: covers,
+	}
+}
+
+func (h *CoversHandler) RegisterRoutes(r *mux.Router) {
+	r.Handle("/movies/{id}/cover", handlerFunc(h.GetCover)).Methods(http.MethodGet, http.MethodHead).Name("getCover")
+}
+
+// GetCover serves a movie's cover image in the requested size, so clients
+// don't have to hotlink the upstream URL. Responses carry an ETag of the
+// image's content hash for conditional requests.
+func (h *CoversHandler) GetCover(w http.ResponseWriter, r *http.Request) error {
+	size := domain.CoverSizeOriginal
+	if v := r.URL.Query().Get("size"); v != "" {
+		size = domain.CoverSize(v)
+		if !domain.ValidCoverSize(size) {
+			return invalidParam("size", "Invalid size, must be original, medium or thumb")
+		}
+	}
+
+	movieID, err := movieIDFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	movie, err := h.movies.GetMovie(r.Context(), movieID)
+	if err != nil {
+		return err
+	}
+
+	cover, err := h.covers.GetCover(r.Context(), movie.CoverURL, size)
+	if err != nil {
+		return err
+	}
+
+	maxAge := coverMaxAge
+	if cover.Placeholder {
+		maxAge = placeholderMaxAge
+	}
+
+	w.Header().Set("Content-Type", cover.ContentType)
+	w.Header().Set("ETag", strconv.Quote(cover.Hash))
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(cover.Data))
+	return nil
+}
@@ -0,0 +1,136 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestGetCover(t *testing.T) {
+	movie := &domain.Movie{ID: 1, Title: "Iron Man", CoverURL: "https://example.com/iron-man.jpg"}
+	cover := &domain.Cover{Data: []byte("jpeg"), ContentType: "image/jpeg", Hash: "abc123"}
+	placeholder := &domain.Cover{Data: []byte("png"), ContentType: "image/png", Hash: "def456", Placeholder: true}
+
+	tt := []struct {
+		Name                 string
+		QueryParams          string
+		IfNoneMatch          string
+		SetupFake            func(movies *domainfakes.FakeMoviesService, covers *domainfakes.FakeCoversService)
+		ExpectedStatus       int
+		ExpectedSize         domain.CoverSize
+		ExpectedBody         string
+		ExpectedCacheControl string
+	}{
+		{
+			Name: "Returns status 200 with the original cover by default",
+			SetupFake: func(movies *domainfakes.FakeMoviesService, covers *domainfakes.FakeCoversService) {
+				movies.GetMovieReturns(movie, nil)
+				covers.GetCoverReturns(cover, nil)
+			},
+			ExpectedStatus:       http.StatusOK,
+			ExpectedSize:         domain.CoverSizeOriginal,
+			ExpectedBody:         "jpeg",
+			ExpectedCacheControl: "public, max-age=86400",
+		},
+		{
+			Name:        "Returns status 200 with a thumbnail",
+			QueryParams: "?size=thumb",
+			SetupFake: func(movies *domainfakes.FakeMoviesService, covers *domainfakes.FakeCoversService) {
+				movies.GetMovieReturns(movie, nil)
+				covers.GetCoverReturns(cover, nil)
+			},
+			ExpectedStatus:       http.StatusOK,
+			ExpectedSize:         domain.CoverSizeThumb,
+			ExpectedBody:         "jpeg",
+			ExpectedCacheControl: "public, max-age=86400",
+		},
+		{
+			Name:        "Returns status 304 when the ETag matches",
+			IfNoneMatch: `"abc123"`,
+			SetupFake: func(movies *domainfakes.FakeMoviesService, covers *domainfakes.FakeCoversService) {
+				movies.GetMovieReturns(movie, nil)
+				covers.GetCoverReturns(cover, nil)
+			},
+			ExpectedStatus:       http.StatusNotModified,
+			ExpectedSize:         domain.CoverSizeOriginal,
+			ExpectedCacheControl: "public, max-age=86400",
+		},
+		{
+			Name: "Returns status 200 with a briefly cached placeholder",
+			SetupFake: func(movies *domainfakes.FakeMoviesService, covers *domainfakes.FakeCoversService) {
+				movies.GetMovieReturns(movie, nil)
+				covers.GetCoverReturns(placeholder, nil)
+			},
+			ExpectedStatus:       http.StatusOK,
+			ExpectedSize:         domain.CoverSizeOriginal,
+			ExpectedBody:         "png",
+			ExpectedCacheControl: "public, max-age=300",
+		},
+		{
+			Name:           "Returns status 400 when size is invalid",
+			QueryParams:    "?size=huge",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name: "Returns status 404 when movie is not found",
+			SetupFake: func(movies *domainfakes.FakeMoviesService, covers *domainfakes.FakeCoversService) {
+				movies.GetMovieReturns(nil, domain.ErrMovieNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name: "Returns status 500 when cover request fails",
+			SetupFake: func(movies *domainfakes.FakeMoviesService, covers *domainfakes.FakeCoversService) {
+				movies.GetMovieReturns(movie, nil)
+				covers.GetCoverReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedSize:   domain.CoverSizeOriginal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			movies := new(domainfakes.FakeMoviesService)
+			covers := new(domainfakes.FakeCoversService)
+			if tc.SetupFake != nil {
+				tc.SetupFake(movies, covers)
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewCoversHandler(movies, covers)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", "/movies/1/cover"+tc.QueryParams, nil)
+			assert.NoError(t, err)
+			if tc.IfNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.IfNoneMatch)
+			}
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if covers.GetCoverCallCount() > 0 {
+				_, sourceURL, size := covers.GetCoverArgsForCall(0)
+				assert.Equal(t, movie.CoverURL, sourceURL)
+				assert.Equal(t, tc.ExpectedSize, size)
+			}
+
+			if tc.ExpectedCacheControl != "" {
+				assert.Equal(t, tc.ExpectedCacheControl, rw.Header().Get("Cache-Control"))
+				assert.NotEmpty(t, rw.Header().Get("ETag"))
+				assert.Equal(t, tc.ExpectedBody, rw.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+const maxMoviesLimit = 100
+
+type moviesQuery struct {
+	filter domain.MovieFilter
+	sortBy domain.MovieSortField
+	order  domain.SortOrder
+	limit  int
+	offset int
+}
+
+func parseMoviesQuery(r *http.Request) (*moviesQuery, error) {
+	q := r.URL.Query()
+	mq := &moviesQuery{
+		filter: domain.MovieFilter{
+			Saga:     q.Get("saga"),
+			Director: q.Get("director"),
+		},
+		sortBy: domain.MovieSortField(q.Get("sort_by")),
+		order:  domain.SortOrder(q.Get("order")),
+	}
+
+	if phases := q.Get("phase"); phases != "" {
+		for _, p := range strings.Split(phases, ",") {
+			phase, err := strconv.Atoi(strings.TrimSpace(p))
+			if err != nil {
+				return nil, invalidParam("phase", "Invalid phase %q", p)
+			}
+			mq.filter.Phases = append(mq.filter.Phases, phase)
+		}
+	}
+
+	ints := []struct {
+		param string
+		dst   *int
+	}{
+		{"released_from", &mq.filter.ReleaseYearFrom},
+		{"released_to", &mq.filter.ReleaseYearTo},
+		{"min_box_office", &mq.filter.MinBoxOffice},
+		{"limit", &mq.limit},
+		{"offset", &mq.offset},
+	}
+	for _, i := range ints {
+		v := q.Get(i.param)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, invalidParam(i.param, "Invalid %s, must be a non-negative integer", i.param)
+		}
+		*i.dst = n
+	}
+
+	if mq.limit > maxMoviesLimit {
+		return nil, invalidParam("limit", "Invalid limit, must be at most %d", maxMoviesLimit)
+	}
+
+	if v := q.Get("post_credit_scenes"); v != "" {
+		has, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, invalidParam("post_credit_scenes", "Invalid post_credit_scenes, must be true or false")
+		}
+		mq.filter.HasPostCreditScenes = &has
+	}
+
+	if mq.sortBy != "" && !domain.ValidMovieSortField(mq.sortBy) {
+		return nil, invalidParam("sort_by", "Invalid sort_by %q", mq.sortBy)
+	}
+
+	switch mq.order {
+	case "":
+		mq.order = domain.SortAsc
+	case domain.SortAsc, domain.SortDesc:
+	default:
+		return nil, invalidParam("order", "Invalid order %q, must be asc or desc", mq.order)
+	}
+
+	return mq, nil
+}
+
+// apply filters, sorts and paginates movies, returning the page along with the
+// number of movies that matched the filter.
+func (mq *moviesQuery) apply(movies domain.Movies) (domain.Movies, int) {
+	movies = movies.Filter(mq.filter)
+	total := len(movies)
+
+	if mq.sortBy != "" {
+		movies = movies.SortBy(mq.sortBy, mq.order)
+	}
+
+	return movies.Paginate(mq.limit, mq.offset), total
+}
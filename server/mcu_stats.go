@@ -0,0 +1,189 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+const (
+	defaultTopGrossing = 3
+	maxTopGrossing     = 20
+)
+
+type statsOverview struct {
+	domain.Aggregates
+	BoxOfficeByYear     []*domain.YearlyBoxOffice     `json:"box_office_by_year"`
+	CumulativeBoxOffice []*domain.CumulativeBoxOffice `json:"cumulative_box_office"`
+	BoxOfficePerMinute  []*domain.BoxOfficePerMinute  `json:"box_office_per_minute"`
+	TopGrossingByPhase  []*domain.PhaseTopGrossing    `json:"top_grossing_by_phase"`
+	SagaYearOverYear    []*domain.SagaYearOverYear    `json:"saga_year_over_year"`
+}
+
+func (h *MCUHandler) GetStats(w http.ResponseWriter, r *http.Request) error {
+	movies, _, err := h.statsMovies(r, nil)
+	if err != nil {
+		return err
+	}
+
+	h.setFreshness(w)
+	respondJSON(w, http.StatusOK, statsOverview{
+		Aggregates:          movies.Aggregate(),
+		BoxOfficeByYear:     movies.BoxOfficeByYear(),
+		CumulativeBoxOffice: movies.CumulativeBoxOffice(),
+		BoxOfficePerMinute:  movies.BoxOfficePerMinute(),
+		TopGrossingByPhase:  movies.TopGrossingByPhase(defaultTopGrossing),
+		SagaYearOverYear:    movies.SagaYearOverYear(),
+	})
+	return nil
+}
+
+func (h *MCUHandler) GetBoxOfficeByYear(w http.ResponseWriter, r *http.Request) error {
+	movies, format, err := h.statsMovies(r, csvFormats)
+	if err != nil {
+		return err
+	}
+
+	years := movies.BoxOfficeByYear()
+	setResultCount(r, len(years))
+	h.setFreshness(w)
+
+	if format == formatCSV {
+		rows := make([][]string, 0, len(years))
+		for _, y := range years {
+			rows = append(rows, []string{itoa(y.Year), itoa(y.TotalBoxOffice), itoa(y.TotalMovies)})
+		}
+		respondCSV(w, "box-office-by-year.csv", []string{"year", "total_box_office", "total_movies"}, rows)
+		return nil
+	}
+
+	respondJSON(w, http.StatusOK, years)
+	return nil
+}
+
+func (h *MCUHandler) GetCumulativeBoxOffice(w http.ResponseWriter, r *http.Request) error {
+	movies, format, err := h.statsMovies(r, csvFormats)
+	if err != nil {
+		return err
+	}
+
+	cumulative := movies.CumulativeBoxOffice()
+	setResultCount(r, len(cumulative))
+	h.setFreshness(w)
+
+	if format == formatCSV {
+		rows := make([][]string, 0, len(cumulative))
+		for _, c := range cumulative {
+			rows = append(rows, []string{itoa(c.MovieID), c.Title, c.ReleaseDate.String(), itoa(c.BoxOffice), itoa(c.CumulativeBoxOffice)})
+		}
+		respondCSV(w, "cumulative-box-office.csv", []string{"movie_id", "title", "release_date", "box_office", "cumulative_box_office"}, rows)
+		return nil
+	}
+
+	respondJSON(w, http.StatusOK, cumulative)
+	return nil
+}
+
+func (h *MCUHandler) GetBoxOfficePerMinute(w http.ResponseWriter, r *http.Request) error {
+	movies, format, err := h.statsMovies(r, csvFormats)
+	if err != nil {
+		return err
+	}
+
+	ranked := movies.BoxOfficePerMinute()
+	setResultCount(r, len(ranked))
+	h.setFreshness(w)
+
+	if format == formatCSV {
+		rows := make([][]string, 0, len(ranked))
+		for _, m := range ranked {
+			rows = append(rows, []string{itoa(m.MovieID), m.Title, itoa(m.BoxOffice), itoa(m.DurationMinutes), ftoa(m.BoxOfficePerMinute)})
+		}
+		respondCSV(w, "box-office-per-minute.csv", []string{"movie_id", "title", "box_office", "duration_minutes", "box_office_per_minute"}, rows)
+		return nil
+	}
+
+	respondJSON(w, http.StatusOK, ranked)
+	return nil
+}
+
+func (h *MCUHandler) GetTopGrossingByPhase(w http.ResponseWriter, r *http.Request) error {
+	n := defaultTopGrossing
+	if v := r.URL.Query().Get("n"); v != "" {
+		var err error
+		n, err = strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxTopGrossing {
+			return invalidParam("n", "Invalid n, must be between 1 and %d", maxTopGrossing)
+		}
+	}
+
+	movies, format, err := h.statsMovies(r, csvFormats)
+	if err != nil {
+		return err
+	}
+
+	top := movies.TopGrossingByPhase(n)
+	setResultCount(r, len(top))
+	h.setFreshness(w)
+
+	if format == formatCSV {
+		var rows [][]string
+		for _, phase := range top {
+			for i, m := range phase.Movies {
+				rows = append(rows, []string{itoa(phase.Phase), itoa(i + 1), itoa(m.ID), m.Title, itoa(m.BoxOffice)})
+			}
+		}
+		respondCSV(w, "top-grossing-by-phase.csv", []string{"phase", "rank", "movie_id", "title", "box_office"}, rows)
+		return nil
+	}
+
+	respondJSON(w, http.StatusOK, top)
+	return nil
+}
+
+func (h *MCUHandler) GetSagaYearOverYear(w http.ResponseWriter, r *http.Request) error {
+	movies, format, err := h.statsMovies(r, csvFormats)
+	if err != nil {
+		return err
+	}
+
+	comparisons := movies.SagaYearOverYear()
+	setResultCount(r, len(comparisons))
+	h.setFreshness(w)
+
+	if format == formatCSV {
+		rows := make([][]string, 0, len(comparisons))
+		for _, c := range comparisons {
+			var prev, change string
+			if c.PreviousYear != 0 {
+				prev = itoa(c.PreviousYear)
+			}
+			if c.ChangePercent != nil {
+				change = ftoa(*c.ChangePercent)
+			}
+			rows = append(rows, []string{c.Saga, itoa(c.Year), itoa(c.TotalBoxOffice), itoa(c.TotalMovies), prev, change})
+		}
+		respondCSV(w, "saga-year-over-year.csv", []string{"saga", "year", "total_box_office", "total_movies", "previous_year", "change_percent"}, rows)
+		return nil
+	}
+
+	respondJSON(w, http.StatusOK, comparisons)
+	return nil
+}
+
+// statsMovies negotiates the response format of a stats request and fetches
+// the movies to compute it from.
+func (h *MCUHandler) statsMovies(r *http.Request, formats map[string]string) (domain.Movies, string, error) {
+	format, err := negotiateFormat(r, formats)
+	if err != nil {
+		return nil, "", err
+	}
+
+	movies, err := h.movies.GetMovies(r.Context())
+	if err != nil {
+		return nil, "", err
+	}
+
+	return movies, format, nil
+}
@@ -0,0 +1,217 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestGetStats(t *testing.T) {
+	movie1 := &domain.Movie{
+		ID:              1,
+		Title:           "Hello World 1",
+		ReleaseDate:     domain.MustParseDate("2010-05-01"),
+		BoxOffice:       1000000,
+		DurationMinutes: 100,
+		Phase:           1,
+		Saga:            "Epilogue",
+	}
+	movie2 := &domain.Movie{
+		ID:              2,
+		Title:           "Hello, World 2",
+		ReleaseDate:     domain.MustParseDate("2010-01-01"),
+		BoxOffice:       3000000,
+		DurationMinutes: 120,
+		Phase:           1,
+		Saga:            "Epilogue",
+	}
+	movie3 := &domain.Movie{
+		ID:              3,
+		Title:           "Hello World 3",
+		ReleaseDate:     domain.MustParseDate("2012-01-01"),
+		BoxOffice:       2000000,
+		DurationMinutes: 80,
+		Phase:           2,
+		Saga:            "Epilogue",
+	}
+	change := -50.0
+
+	tt := []struct {
+		Name           string
+		Endpoint       string
+		Accept         string
+		SetupFake      func(fake *domainfakes.FakeMoviesService)
+		ExpectedStatus int
+		ExpectedBody   interface{}
+		ExpectedCSV    string
+	}{
+		{
+			Name:           "Returns box office by year",
+			Endpoint:       "/stats/box-office/by-year",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: []*domain.YearlyBoxOffice{
+				{Year: 2010, TotalBoxOffice: 4000000, TotalMovies: 2},
+				{Year: 2012, TotalBoxOffice: 2000000, TotalMovies: 1},
+			},
+		},
+		{
+			Name:           "Returns box office by year as CSV",
+			Endpoint:       "/stats/box-office/by-year?format=csv",
+			ExpectedStatus: http.StatusOK,
+			ExpectedCSV:    "year,total_box_office,total_movies\n2010,4000000,2\n2012,2000000,1\n",
+		},
+		{
+			Name:           "Returns cumulative box office as CSV when requested by Accept header",
+			Endpoint:       "/stats/box-office/cumulative",
+			Accept:         "text/csv",
+			ExpectedStatus: http.StatusOK,
+			ExpectedCSV: "movie_id,title,release_date,box_office,cumulative_box_office\n" +
+				"2,\"Hello, World 2\",2010-01-01,3000000,3000000\n" +
+				"1,Hello World 1,2010-05-01,1000000,4000000\n" +
+				"3,Hello World 3,2012-01-01,2000000,6000000\n",
+		},
+		{
+			Name:     "Neutralises formulas in CSV cells",
+			Endpoint: "/stats/box-office/cumulative?format=csv",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{
+					{ID: 1, Title: "=HYPERLINK(\"http://evil.example\")", ReleaseDate: domain.MustParseDate("2010-01-01"), BoxOffice: 1000000},
+					{ID: 2, Title: "@SUM(A1:A2)", ReleaseDate: domain.MustParseDate("2011-01-01"), BoxOffice: 2000000},
+					{ID: 3, Title: "-Hello World", ReleaseDate: domain.MustParseDate("2012-01-01"), BoxOffice: 3000000},
+				}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedCSV: "movie_id,title,release_date,box_office,cumulative_box_office\n" +
+				"1,\"'=HYPERLINK(\"\"http://evil.example\"\")\",2010-01-01,1000000,1000000\n" +
+				"2,'@SUM(A1:A2),2011-01-01,2000000,3000000\n" +
+				"3,'-Hello World,2012-01-01,3000000,6000000\n",
+		},
+		{
+			Name:           "Returns box office per minute",
+			Endpoint:       "/stats/box-office/per-minute",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: []*domain.BoxOfficePerMinute{
+				{MovieID: 2, Title: "Hello, World 2", BoxOffice: 3000000, DurationMinutes: 120, BoxOfficePerMinute: 25000},
+				{MovieID: 3, Title: "Hello World 3", BoxOffice: 2000000, DurationMinutes: 80, BoxOfficePerMinute: 25000},
+				{MovieID: 1, Title: "Hello World 1", BoxOffice: 1000000, DurationMinutes: 100, BoxOfficePerMinute: 10000},
+			},
+		},
+		{
+			Name:           "Returns top grossing movies by phase",
+			Endpoint:       "/stats/box-office/top-by-phase?n=1",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: []*domain.PhaseTopGrossing{
+				{Phase: 1, Movies: domain.Movies{movie2}},
+				{Phase: 2, Movies: domain.Movies{movie3}},
+			},
+		},
+		{
+			Name:           "Returns top grossing movies by phase as CSV",
+			Endpoint:       "/stats/box-office/top-by-phase?format=csv",
+			ExpectedStatus: http.StatusOK,
+			ExpectedCSV: "phase,rank,movie_id,title,box_office\n" +
+				"1,1,2,\"Hello, World 2\",3000000\n" +
+				"1,2,1,Hello World 1,1000000\n" +
+				"2,1,3,Hello World 3,2000000\n",
+		},
+		{
+			Name:           "Returns saga year-over-year comparison",
+			Endpoint:       "/stats/sagas/year-over-year",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: []*domain.SagaYearOverYear{
+				{Saga: "Epilogue", Year: 2010, TotalBoxOffice: 4000000, TotalMovies: 2},
+				{Saga: "Epilogue", Year: 2012, TotalBoxOffice: 2000000, TotalMovies: 1, PreviousYear: 2010, ChangePercent: &change},
+			},
+		},
+		{
+			Name:           "Returns saga year-over-year comparison as CSV",
+			Endpoint:       "/stats/sagas/year-over-year?format=csv",
+			ExpectedStatus: http.StatusOK,
+			ExpectedCSV: "saga,year,total_box_office,total_movies,previous_year,change_percent\n" +
+				"Epilogue,2010,4000000,2,,\n" +
+				"Epilogue,2012,2000000,1,2010,-50\n",
+		},
+		{
+			Name:           "Returns status 400 when n is invalid",
+			Endpoint:       "/stats/box-office/top-by-phase?n=0",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when format is invalid",
+			Endpoint:       "/stats/box-office/by-year?format=xlsx",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when overview is requested as CSV",
+			Endpoint:       "/stats?format=csv",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "Returns status 500 when service request fails",
+			Endpoint: "/stats",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMoviesReturns(domain.Movies{movie1, movie2, movie3}, nil)
+			if tc.SetupFake != nil {
+				tc.SetupFake(service)
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", tc.Endpoint, nil)
+			assert.NoError(t, err)
+			if tc.Accept != "" {
+				req.Header.Set("Accept", tc.Accept)
+			}
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if tc.ExpectedCSV != "" {
+				assert.Equal(t, "text/csv; charset=utf-8", rw.Header().Get("Content-Type"))
+				assert.Contains(t, rw.Header().Get("Content-Disposition"), "attachment")
+				assert.Equal(t, tc.ExpectedCSV, rw.Body.String())
+			}
+
+			switch expected := tc.ExpectedBody.(type) {
+			case []*domain.YearlyBoxOffice:
+				var res []*domain.YearlyBoxOffice
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			case []*domain.BoxOfficePerMinute:
+				var res []*domain.BoxOfficePerMinute
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			case []*domain.PhaseTopGrossing:
+				var res []*domain.PhaseTopGrossing
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			case []*domain.SagaYearOverYear:
+				var res []*domain.SagaYearOverYear
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package server_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,12 +10,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/jace-ys/simple-api/domain"
 	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/search"
 	"github.com/jace-ys/simple-api/server"
 )
 
@@ -26,18 +29,23 @@ func TestGetMovies(t *testing.T) {
 	movie := &domain.Movie{
 		ID:               4,
 		Title:            "Hello World",
-		ReleaseDate:      "2000-01-01",
+		ReleaseDate:      domain.MustParseDate("2000-01-01"),
 		BoxOffice:        1000000,
 		DurationMinutes:  120,
 		Overview:         "This is a fantastic movie.",
+		CoverURL:         "https://example.com/hello-world.jpg",
+		TrailerURL:       "https://example.com/hello-world.mp4",
+		DirectedBy:       "Jane Doe",
 		Phase:            1,
 		Saga:             "Finale",
 		Chronology:       4,
 		PostCreditScenes: 2,
+		ImdbID:           "tt0000004",
 	}
 
 	tt := []struct {
 		Name           string
+		QueryParams    string
 		SetupFake      func(fake *domainfakes.FakeMoviesService)
 		ExpectedStatus int
 		ExpectedBody   domain.Movies
@@ -50,6 +58,27 @@ func TestGetMovies(t *testing.T) {
 			ExpectedStatus: http.StatusOK,
 			ExpectedBody:   domain.Movies{movie},
 		},
+		{
+			Name:        "Returns status 200 with selected fields",
+			QueryParams: "?fields=id,title,cover_url",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{movie}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: domain.Movies{
+				{
+					ID:       4,
+					Title:    "Hello World",
+					CoverURL: "https://example.com/hello-world.jpg",
+				},
+			},
+		},
+		{
+			Name:           "Returns status 400 when fields are invalid",
+			QueryParams:    "?fields=id,budget",
+			SetupFake:      func(fake *domainfakes.FakeMoviesService) {},
+			ExpectedStatus: http.StatusBadRequest,
+		},
 		{
 			Name: "Returns status 500 when service request fails",
 			SetupFake: func(fake *domainfakes.FakeMoviesService) {
@@ -68,7 +97,7 @@ func TestGetMovies(t *testing.T) {
 			handler := server.NewMCUHandler(service)
 			handler.RegisterRoutes(router)
 
-			req, err := http.NewRequest("GET", "/movies", nil)
+			req, err := http.NewRequest("GET", "/movies"+tc.QueryParams, nil)
 			assert.NoError(t, err)
 
 			rw := httptest.NewRecorder()
@@ -89,19 +118,24 @@ func TestGetMovie(t *testing.T) {
 	movie := &domain.Movie{
 		ID:               4,
 		Title:            "Hello World",
-		ReleaseDate:      "2000-01-01",
+		ReleaseDate:      domain.MustParseDate("2000-01-01"),
 		BoxOffice:        1000000,
 		DurationMinutes:  120,
 		Overview:         "This is a fantastic movie.",
+		CoverURL:         "https://example.com/hello-world.jpg",
+		TrailerURL:       "https://example.com/hello-world.mp4",
+		DirectedBy:       "Jane Doe",
 		Phase:            1,
 		Saga:             "Finale",
 		Chronology:       4,
 		PostCreditScenes: 2,
+		ImdbID:           "tt0000004",
 	}
 
 	tt := []struct {
 		Name           string
 		PathParamID    string
+		QueryParams    string
 		SetupFake      func(fake *domainfakes.FakeMoviesService)
 		ExpectedStatus int
 		ExpectedBody   *domain.Movie
@@ -115,6 +149,26 @@ func TestGetMovie(t *testing.T) {
 			ExpectedStatus: http.StatusOK,
 			ExpectedBody:   movie,
 		},
+		{
+			Name:        "Returns status 200 with selected fields",
+			PathParamID: "4",
+			QueryParams: "?fields=title,directed_by,imdb_id",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMovieReturns(movie, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: &domain.Movie{
+				Title:      "Hello World",
+				DirectedBy: "Jane Doe",
+				ImdbID:     "tt0000004",
+			},
+		},
+		{
+			Name:           "Returns status 400 when fields are invalid",
+			PathParamID:    "4",
+			QueryParams:    "?fields=budget",
+			ExpectedStatus: http.StatusBadRequest,
+		},
 		{
 			Name:           "Returns status 400 when ID is invalid",
 			PathParamID:    "test",
@@ -149,7 +203,7 @@ func TestGetMovie(t *testing.T) {
 			handler := server.NewMCUHandler(service)
 			handler.RegisterRoutes(router)
 
-			endpoint := fmt.Sprintf("/movies/%s", tc.PathParamID)
+			endpoint := fmt.Sprintf("/movies/%s%s", tc.PathParamID, tc.QueryParams)
 			req, err := http.NewRequest("GET", endpoint, nil)
 			assert.NoError(t, err)
 
@@ -171,7 +225,7 @@ func TestGetSagas(t *testing.T) {
 	movie1 := &domain.Movie{
 		ID:               4,
 		Title:            "Hello World 4",
-		ReleaseDate:      "2000-01-01",
+		ReleaseDate:      domain.MustParseDate("2000-01-01"),
 		BoxOffice:        1000000,
 		DurationMinutes:  120,
 		Overview:         "This is a fantastic movie.",
@@ -183,7 +237,7 @@ func TestGetSagas(t *testing.T) {
 	movie2 := &domain.Movie{
 		ID:               6,
 		Title:            "Hello World 6",
-		ReleaseDate:      "2010-01-01",
+		ReleaseDate:      domain.MustParseDate("2010-01-01"),
 		BoxOffice:        1000000,
 		DurationMinutes:  120,
 		Overview:         "This is another fantastic movie.",
@@ -195,7 +249,7 @@ func TestGetSagas(t *testing.T) {
 	movie3 := &domain.Movie{
 		ID:               10,
 		Title:            "Hello World 10",
-		ReleaseDate:      "2020-01-01",
+		ReleaseDate:      domain.MustParseDate("2020-01-01"),
 		BoxOffice:        1000000,
 		DurationMinutes:  120,
 		Overview:         "This is another another fantastic movie.",
@@ -204,6 +258,14 @@ func TestGetSagas(t *testing.T) {
 		Chronology:       10,
 		PostCreditScenes: 2,
 	}
+	unannounced := &domain.Movie{
+		ID:         12,
+		Title:      "Hello World 12",
+		Phase:      2,
+		Saga:       "Epilogue",
+		Chronology: 12,
+		Upcoming:   true,
+	}
 
 	tt := []struct {
 		Name           string
@@ -220,33 +282,65 @@ func TestGetSagas(t *testing.T) {
 			ExpectedStatus: http.StatusOK,
 			ExpectedBody: domain.Sagas{
 				{
-					Name:                 "Epilogue",
-					StartDate:            "2000-01-01",
-					EndDate:              "2010-01-01",
-					TotalBoxOffice:       2000000,
-					TotalDurationMinutes: 240,
-					TotalMovies:          2,
+					Name: "Epilogue",
+					Slug: "epilogue",
+					Aggregates: domain.Aggregates{
+						StartDate:            domain.MustParseDate("2000-01-01"),
+						EndDate:              domain.MustParseDate("2010-01-01"),
+						TotalBoxOffice:       2000000,
+						TotalDurationMinutes: 240,
+						TotalMovies:          2,
+						AvgPostCreditScenes:  2,
+					},
 					Phases: domain.Phases{
 						{
 							Number: 1,
+							Aggregates: domain.Aggregates{
+								StartDate:            domain.MustParseDate("2000-01-01"),
+								EndDate:              domain.MustParseDate("2000-01-01"),
+								TotalBoxOffice:       1000000,
+								TotalDurationMinutes: 120,
+								TotalMovies:          1,
+								AvgPostCreditScenes:  2,
+							},
 							Movies: domain.Movies{movie1},
 						},
 						{
 							Number: 2,
+							Aggregates: domain.Aggregates{
+								StartDate:            domain.MustParseDate("2010-01-01"),
+								EndDate:              domain.MustParseDate("2010-01-01"),
+								TotalBoxOffice:       1000000,
+								TotalDurationMinutes: 120,
+								TotalMovies:          1,
+								AvgPostCreditScenes:  2,
+							},
 							Movies: domain.Movies{movie2},
 						},
 					},
 				},
 				{
-					Name:                 "Finale",
-					StartDate:            "2020-01-01",
-					EndDate:              "2020-01-01",
-					TotalBoxOffice:       1000000,
-					TotalDurationMinutes: 120,
-					TotalMovies:          1,
+					Name: "Finale",
+					Slug: "finale",
+					Aggregates: domain.Aggregates{
+						StartDate:            domain.MustParseDate("2020-01-01"),
+						EndDate:              domain.MustParseDate("2020-01-01"),
+						TotalBoxOffice:       1000000,
+						TotalDurationMinutes: 120,
+						TotalMovies:          1,
+						AvgPostCreditScenes:  2,
+					},
 					Phases: domain.Phases{
 						{
 							Number: 4,
+							Aggregates: domain.Aggregates{
+								StartDate:            domain.MustParseDate("2020-01-01"),
+								EndDate:              domain.MustParseDate("2020-01-01"),
+								TotalBoxOffice:       1000000,
+								TotalDurationMinutes: 120,
+								TotalMovies:          1,
+								AvgPostCreditScenes:  2,
+							},
 							Movies: domain.Movies{movie3},
 						},
 					},
@@ -261,24 +355,90 @@ func TestGetSagas(t *testing.T) {
 			},
 			ExpectedStatus: http.StatusOK,
 			ExpectedBody: &domain.Saga{
-				Name:                 "Epilogue",
-				StartDate:            "2000-01-01",
-				EndDate:              "2010-01-01",
-				TotalBoxOffice:       2000000,
-				TotalDurationMinutes: 240,
-				TotalMovies:          2,
+				Name: "Epilogue",
+				Slug: "epilogue",
+				Aggregates: domain.Aggregates{
+					StartDate:            domain.MustParseDate("2000-01-01"),
+					EndDate:              domain.MustParseDate("2010-01-01"),
+					TotalBoxOffice:       2000000,
+					TotalDurationMinutes: 240,
+					TotalMovies:          2,
+					AvgPostCreditScenes:  2,
+				},
 				Phases: domain.Phases{
 					{
 						Number: 1,
+						Aggregates: domain.Aggregates{
+							StartDate:            domain.MustParseDate("2000-01-01"),
+							EndDate:              domain.MustParseDate("2000-01-01"),
+							TotalBoxOffice:       1000000,
+							TotalDurationMinutes: 120,
+							TotalMovies:          1,
+							AvgPostCreditScenes:  2,
+						},
 						Movies: domain.Movies{movie1},
 					},
 					{
 						Number: 2,
+						Aggregates: domain.Aggregates{
+							StartDate:            domain.MustParseDate("2010-01-01"),
+							EndDate:              domain.MustParseDate("2010-01-01"),
+							TotalBoxOffice:       1000000,
+							TotalDurationMinutes: 120,
+							TotalMovies:          1,
+							AvgPostCreditScenes:  2,
+						},
 						Movies: domain.Movies{movie2},
 					},
 				},
 			},
 		},
+		{
+			Name:       "Returns status 200 with date span independent of movie order",
+			QueryParam: "?name=epilogue",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{movie2, unannounced, movie1}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: &domain.Saga{
+				Name: "Epilogue",
+				Slug: "epilogue",
+				Aggregates: domain.Aggregates{
+					StartDate:            domain.MustParseDate("2000-01-01"),
+					EndDate:              domain.MustParseDate("2010-01-01"),
+					TotalBoxOffice:       2000000,
+					TotalDurationMinutes: 240,
+					TotalMovies:          3,
+					AvgPostCreditScenes:  1.33,
+				},
+				Phases: domain.Phases{
+					{
+						Number: 1,
+						Aggregates: domain.Aggregates{
+							StartDate:            domain.MustParseDate("2000-01-01"),
+							EndDate:              domain.MustParseDate("2000-01-01"),
+							TotalBoxOffice:       1000000,
+							TotalDurationMinutes: 120,
+							TotalMovies:          1,
+							AvgPostCreditScenes:  2,
+						},
+						Movies: domain.Movies{movie1},
+					},
+					{
+						Number: 2,
+						Aggregates: domain.Aggregates{
+							StartDate:            domain.MustParseDate("2010-01-01"),
+							EndDate:              domain.MustParseDate("2010-01-01"),
+							TotalBoxOffice:       1000000,
+							TotalDurationMinutes: 120,
+							TotalMovies:          2,
+							AvgPostCreditScenes:  1,
+						},
+						Movies: domain.Movies{movie2, unannounced},
+					},
+				},
+			},
+		},
 		{
 			Name:           "Returns status 404 when not found",
 			QueryParam:     "?name=invalid",
@@ -327,3 +487,579 @@ func TestGetSagas(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSaga(t *testing.T) {
+	movies := domain.Movies{
+		{ID: 1, Title: "Hello World 1", ReleaseDate: domain.MustParseDate("2008-05-02"), Phase: 1, Saga: "Infinity Saga"},
+		{ID: 2, Title: "Hello World 2", ReleaseDate: domain.MustParseDate("2021-07-09"), Phase: 4, Saga: "Multiverse Saga"},
+		{ID: 3, Title: "Hello World 3", ReleaseDate: domain.MustParseDate("2030-01-01"), Phase: 7, Saga: "Mutant Saga"},
+		{ID: 4, Title: "Hello World 4", ReleaseDate: domain.MustParseDate("2040-01-01"), Phase: 9, Saga: "Épilogue"},
+	}
+
+	tt := []struct {
+		Name                string
+		Endpoint            string
+		ExpectedStatus      int
+		ExpectedSlug        string
+		ExpectedSuggestions []string
+	}{
+		{
+			Name:           "Returns status 200 when matched by slug",
+			Endpoint:       "/sagas/infinity-saga",
+			ExpectedStatus: http.StatusOK,
+			ExpectedSlug:   "infinity-saga",
+		},
+		{
+			Name:           "Returns status 200 when matched by name in any case",
+			Endpoint:       "/sagas/MULTIVERSE%20SAGA",
+			ExpectedStatus: http.StatusOK,
+			ExpectedSlug:   "multiverse-saga",
+		},
+		{
+			Name:           "Returns status 200 when matched by unique prefix",
+			Endpoint:       "/sagas/infinity",
+			ExpectedStatus: http.StatusOK,
+			ExpectedSlug:   "infinity-saga",
+		},
+		{
+			Name:           "Returns status 200 when matched without accents",
+			Endpoint:       "/sagas/epilogue",
+			ExpectedStatus: http.StatusOK,
+			ExpectedSlug:   "epilogue",
+		},
+		{
+			Name:           "Returns status 200 when matched by query param",
+			Endpoint:       "/sagas?name=mutant",
+			ExpectedStatus: http.StatusOK,
+			ExpectedSlug:   "mutant-saga",
+		},
+		{
+			Name:                "Returns status 404 with suggestions when prefix is ambiguous",
+			Endpoint:            "/sagas/mu",
+			ExpectedStatus:      http.StatusNotFound,
+			ExpectedSuggestions: []string{"multiverse-saga", "mutant-saga"},
+		},
+		{
+			Name:                "Returns status 404 with suggestions when misspelled",
+			Endpoint:            "/sagas/infnity",
+			ExpectedStatus:      http.StatusNotFound,
+			ExpectedSuggestions: []string{"infinity-saga"},
+		},
+		{
+			Name:           "Returns status 404 without suggestions when nothing is close",
+			Endpoint:       "/sagas/invalid",
+			ExpectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMoviesReturns(movies, nil)
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", tc.Endpoint, nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if tc.ExpectedSlug != "" {
+				var res *domain.Saga
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, tc.ExpectedSlug, res.Slug)
+			}
+
+			if tc.ExpectedStatus == http.StatusNotFound {
+				var res *server.Problem
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, server.CodeSagaNotFound, res.Code)
+				assert.Equal(t, tc.ExpectedSuggestions, res.Suggestions)
+			}
+		})
+	}
+}
+
+func TestGetSagaPhases(t *testing.T) {
+	movie1 := &domain.Movie{
+		ID:               1,
+		Title:            "Hello World 1",
+		ReleaseDate:      domain.MustParseDate("2002-01-01"),
+		BoxOffice:        1000000,
+		DurationMinutes:  120,
+		Phase:            1,
+		Saga:             "Epilogue",
+		PostCreditScenes: 1,
+	}
+	movie2 := &domain.Movie{
+		ID:               2,
+		Title:            "Hello World 2",
+		ReleaseDate:      domain.MustParseDate("2000-01-01"),
+		BoxOffice:        2000000,
+		DurationMinutes:  100,
+		Phase:            1,
+		Saga:             "Epilogue",
+		PostCreditScenes: 2,
+	}
+	movie3 := &domain.Movie{
+		ID:               3,
+		Title:            "Hello World 3",
+		ReleaseDate:      domain.MustParseDate("2010-01-01"),
+		BoxOffice:        3000000,
+		DurationMinutes:  90,
+		Phase:            2,
+		Saga:             "Epilogue",
+		PostCreditScenes: 0,
+	}
+
+	phase1 := &domain.Phase{
+		Number: 1,
+		Aggregates: domain.Aggregates{
+			StartDate:            domain.MustParseDate("2000-01-01"),
+			EndDate:              domain.MustParseDate("2002-01-01"),
+			TotalBoxOffice:       3000000,
+			TotalDurationMinutes: 220,
+			TotalMovies:          2,
+			AvgPostCreditScenes:  1.5,
+		},
+		Movies: domain.Movies{movie1, movie2},
+	}
+	phase2 := &domain.Phase{
+		Number: 2,
+		Aggregates: domain.Aggregates{
+			StartDate:            domain.MustParseDate("2010-01-01"),
+			EndDate:              domain.MustParseDate("2010-01-01"),
+			TotalBoxOffice:       3000000,
+			TotalDurationMinutes: 90,
+			TotalMovies:          1,
+			AvgPostCreditScenes:  0,
+		},
+		Movies: domain.Movies{movie3},
+	}
+
+	tt := []struct {
+		Name           string
+		Endpoint       string
+		SetupFake      func(fake *domainfakes.FakeMoviesService)
+		ExpectedStatus int
+		ExpectedBody   interface{}
+	}{
+		{
+			Name:     "Returns status 200 with all phases",
+			Endpoint: "/sagas/epilogue/phases",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{movie1, movie2, movie3}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Phases{phase1, phase2},
+		},
+		{
+			Name:     "Returns status 200 with a single phase",
+			Endpoint: "/sagas/epilogue/phases/1",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{movie1, movie2, movie3}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   phase1,
+		},
+		{
+			Name:           "Returns status 400 when phase number is invalid",
+			Endpoint:       "/sagas/epilogue/phases/one",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "Returns status 404 when saga is not found",
+			Endpoint: "/sagas/invalid/phases",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{movie1, movie2, movie3}, nil)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 404 when phase is not found",
+			Endpoint: "/sagas/epilogue/phases/4",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{movie1, movie2, movie3}, nil)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 500 when service request fails",
+			Endpoint: "/sagas/epilogue/phases",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			if tc.SetupFake != nil {
+				tc.SetupFake(service)
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", tc.Endpoint, nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			switch expected := tc.ExpectedBody.(type) {
+			case domain.Phases:
+				var res domain.Phases
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			case *domain.Phase:
+				var res *domain.Phase
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			}
+		})
+	}
+}
+
+type fakeSnapshotMoviesService struct {
+	*domainfakes.FakeMoviesService
+	loadedAt time.Time
+}
+
+func (f *fakeSnapshotMoviesService) LoadedAt() time.Time {
+	return f.loadedAt
+}
+
+func TestMoviesFreshnessHeaders(t *testing.T) {
+	loadedAt := time.Now().Add(-90 * time.Second)
+
+	service := &fakeSnapshotMoviesService{
+		FakeMoviesService: new(domainfakes.FakeMoviesService),
+		loadedAt:          loadedAt,
+	}
+	service.GetMoviesReturns(domain.Movies{{ID: 1}}, nil)
+	service.GetMovieReturns(&domain.Movie{ID: 1}, nil)
+
+	router := mux.NewRouter()
+	handler := server.NewMCUHandler(service)
+	handler.RegisterRoutes(router)
+
+	for _, endpoint := range []string{"/movies", "/movies/1", "/sagas"} {
+		req, err := http.NewRequest("GET", endpoint, nil)
+		assert.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "90", rw.Header().Get("Age"))
+		assert.Equal(t, loadedAt.UTC().Format(http.TimeFormat), rw.Header().Get("Last-Modified"))
+	}
+}
+
+func TestGetMoviesQuery(t *testing.T) {
+	ironMan := &domain.Movie{
+		ID:               1,
+		Title:            "Iron Man",
+		ReleaseDate:      domain.MustParseDate("2008-05-02"),
+		BoxOffice:        585171547,
+		DurationMinutes:  126,
+		DirectedBy:       "Jon Favreau",
+		Phase:            1,
+		Saga:             "Infinity Saga",
+		Chronology:       3,
+		PostCreditScenes: 1,
+	}
+	captainAmerica := &domain.Movie{
+		ID:               5,
+		Title:            "Captain America: The First Avenger",
+		ReleaseDate:      domain.MustParseDate("2011-07-22"),
+		BoxOffice:        370569774,
+		DurationMinutes:  124,
+		DirectedBy:       "Joe Johnston",
+		Phase:            1,
+		Saga:             "Infinity Saga",
+		Chronology:       1,
+		PostCreditScenes: 1,
+	}
+	endgame := &domain.Movie{
+		ID:               22,
+		Title:            "Avengers: Endgame",
+		ReleaseDate:      domain.MustParseDate("2019-04-26"),
+		BoxOffice:        2797501328,
+		DurationMinutes:  181,
+		DirectedBy:       "Anthony Russo, Joe Russo",
+		Phase:            3,
+		Saga:             "Infinity Saga",
+		Chronology:       22,
+		PostCreditScenes: 0,
+	}
+	eternals := &domain.Movie{
+		ID:               26,
+		Title:            "Eternals",
+		ReleaseDate:      domain.MustParseDate("2021-11-05"),
+		BoxOffice:        402064899,
+		DurationMinutes:  156,
+		DirectedBy:       "Chloé Zhao",
+		Phase:            4,
+		Saga:             "Multiverse Saga",
+		Chronology:       26,
+		PostCreditScenes: 2,
+	}
+	movies := domain.Movies{ironMan, captainAmerica, endgame, eternals}
+
+	tt := []struct {
+		Name           string
+		QueryParams    string
+		ExpectedStatus int
+		ExpectedBody   domain.Movies
+		ExpectedTotal  string
+	}{
+		{
+			Name:           "Filters by saga",
+			QueryParams:    "?saga=multiverse%20saga",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{eternals},
+			ExpectedTotal:  "1",
+		},
+		{
+			Name:           "Filters by phases",
+			QueryParams:    "?phase=3,4",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{endgame, eternals},
+			ExpectedTotal:  "2",
+		},
+		{
+			Name:           "Filters by director",
+			QueryParams:    "?director=russo",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{endgame},
+			ExpectedTotal:  "1",
+		},
+		{
+			Name:           "Filters by release year range",
+			QueryParams:    "?released_from=2011&released_to=2019",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{captainAmerica, endgame},
+			ExpectedTotal:  "2",
+		},
+		{
+			Name:           "Filters by minimum box office and post-credit scenes",
+			QueryParams:    "?min_box_office=400000000&post_credit_scenes=true",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{ironMan, eternals},
+			ExpectedTotal:  "2",
+		},
+		{
+			Name:           "Sorts by chronology",
+			QueryParams:    "?sort_by=chronology",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{captainAmerica, ironMan, endgame, eternals},
+			ExpectedTotal:  "4",
+		},
+		{
+			Name:           "Sorts by box office descending",
+			QueryParams:    "?sort_by=box_office&order=desc",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{endgame, ironMan, eternals, captainAmerica},
+			ExpectedTotal:  "4",
+		},
+		{
+			Name:           "Sorts by title",
+			QueryParams:    "?sort_by=title",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{endgame, captainAmerica, eternals, ironMan},
+			ExpectedTotal:  "4",
+		},
+		{
+			Name:           "Paginates after sorting",
+			QueryParams:    "?sort_by=duration&limit=2&offset=1",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{ironMan, eternals},
+			ExpectedTotal:  "4",
+		},
+		{
+			Name:           "Returns empty page past the end",
+			QueryParams:    "?offset=10",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{},
+			ExpectedTotal:  "4",
+		},
+		{
+			Name:           "Returns status 400 when sort field is invalid",
+			QueryParams:    "?sort_by=budget",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when order is invalid",
+			QueryParams:    "?sort_by=title&order=up",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when limit is invalid",
+			QueryParams:    "?limit=-1",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when phase is invalid",
+			QueryParams:    "?phase=one",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMoviesStub = func(ctx context.Context) (domain.Movies, error) {
+				return append(domain.Movies{}, movies...), nil
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", "/movies"+tc.QueryParams, nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if tc.ExpectedBody != nil {
+				var res domain.Movies
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, tc.ExpectedBody, res)
+				assert.Equal(t, tc.ExpectedTotal, rw.Header().Get("X-Total-Count"))
+			}
+		})
+	}
+}
+
+func TestSearchMovies(t *testing.T) {
+	winterSoldier := &domain.Movie{
+		ID:       9,
+		Title:    "Captain America: The Winter Soldier",
+		Overview: "Steve Rogers battles the Soviet agent known as the Winter Soldier.",
+	}
+	guardians := &domain.Movie{
+		ID:       10,
+		Title:    "Guardians of the Galaxy",
+		Overview: "Brash space adventurer Peter Quill finds himself the object of a manhunt.",
+	}
+
+	tt := []struct {
+		Name           string
+		QueryParams    string
+		SetupFake      func(fake *domainfakes.FakeMoviesService)
+		ExpectedStatus int
+		ExpectedIDs    []int
+	}{
+		{
+			Name:        "Returns status 200 with ranked results",
+			QueryParams: "?q=winter+soldjer",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{winterSoldier, guardians}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedIDs:    []int{9},
+		},
+		{
+			Name:        "Returns status 200 with no results",
+			QueryParams: "?q=thanos",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(domain.Movies{winterSoldier, guardians}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedIDs:    []int{},
+		},
+		{
+			Name:           "Returns status 400 when query is missing",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when limit is invalid",
+			QueryParams:    "?q=guardians&limit=0",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:        "Returns status 500 when service request fails",
+			QueryParams: "?q=guardians",
+			SetupFake: func(fake *domainfakes.FakeMoviesService) {
+				fake.GetMoviesReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			if tc.SetupFake != nil {
+				tc.SetupFake(service)
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", "/movies/search"+tc.QueryParams, nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if tc.ExpectedIDs != nil {
+				var res []*search.Result
+				json.NewDecoder(rw.Body).Decode(&res)
+
+				ids := []int{}
+				for _, r := range res {
+					ids = append(ids, r.Movie.ID)
+				}
+				assert.Equal(t, tc.ExpectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestSearchMoviesReusesIndex(t *testing.T) {
+	service := &fakeSnapshotMoviesService{
+		FakeMoviesService: new(domainfakes.FakeMoviesService),
+		loadedAt:          time.Now(),
+	}
+	service.GetMoviesReturns(domain.Movies{{ID: 1, Title: "Iron Man"}}, nil)
+
+	router := mux.NewRouter()
+	handler := server.NewMCUHandler(service)
+	handler.RegisterRoutes(router)
+
+	search := func() {
+		req, err := http.NewRequest("GET", "/movies/search?q=iron", nil)
+		assert.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	}
+
+	search()
+	search()
+	assert.Equal(t, 1, service.GetMoviesCallCount())
+
+	service.loadedAt = service.loadedAt.Add(time.Minute)
+	search()
+	assert.Equal(t, 2, service.GetMoviesCallCount())
+}
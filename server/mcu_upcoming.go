@@ -0,0 +1,23 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+// GetUpcoming lists the movies yet to be released with a countdown to each
+// release, including ones whose release date is still to be announced.
+func (h *MCUHandler) GetUpcoming(w http.ResponseWriter, r *http.Request) error {
+	movies, err := h.movies.GetMovies(r.Context())
+	if err != nil {
+		return err
+	}
+
+	upcoming := movies.Upcoming(domain.Today())
+
+	setResultCount(r, len(upcoming))
+	h.setFreshness(w)
+	respondJSON(w, http.StatusOK, upcoming)
+	return nil
+}
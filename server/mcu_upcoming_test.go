@@ -0,0 +1,68 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestGetUpcoming(t *testing.T) {
+	today := domain.Today()
+	daysFromToday := func(days int) domain.Date {
+		return domain.DateOf(today.Time().AddDate(0, 0, days))
+	}
+
+	released := &domain.Movie{ID: 1, Title: "Released", ReleaseDate: daysFromToday(-30)}
+	tomorrow := &domain.Movie{ID: 2, Title: "Tomorrow", ReleaseDate: daysFromToday(1)}
+	nextMonth := &domain.Movie{ID: 3, Title: "Next Month", ReleaseDate: daysFromToday(30)}
+	unannounced := &domain.Movie{ID: 4, Title: "Unannounced"}
+
+	type upcomingRelease struct {
+		Movie struct {
+			ID          int     `json:"id"`
+			ReleaseDate *string `json:"release_date"`
+			Upcoming    bool    `json:"upcoming"`
+		} `json:"movie"`
+		DaysUntilRelease *int `json:"days_until_release"`
+	}
+
+	service := new(domainfakes.FakeMoviesService)
+	service.GetMoviesReturns(domain.Movies{unannounced, nextMonth, released, tomorrow}, nil)
+
+	router := mux.NewRouter()
+	handler := server.NewMCUHandler(service)
+	handler.RegisterRoutes(router)
+
+	req, err := http.NewRequest("GET", "/upcoming", nil)
+	assert.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, req)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	var res []upcomingRelease
+	assert.NoError(t, json.NewDecoder(rw.Body).Decode(&res))
+	assert.Len(t, res, 3)
+
+	var ids []int
+	for _, release := range res {
+		ids = append(ids, release.Movie.ID)
+		assert.True(t, release.Movie.Upcoming)
+	}
+	assert.Equal(t, []int{2, 3, 4}, ids)
+
+	assert.Equal(t, 1, *res[0].DaysUntilRelease)
+	assert.Equal(t, tomorrow.ReleaseDate.String(), *res[0].Movie.ReleaseDate)
+	assert.Equal(t, 30, *res[1].DaysUntilRelease)
+	assert.Nil(t, res[2].DaysUntilRelease)
+	assert.Nil(t, res[2].Movie.ReleaseDate)
+}
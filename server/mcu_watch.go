@@ -0,0 +1,148 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+const (
+	defaultMarathonHoursPerDay  = 8
+	defaultMarathonBreakMinutes = 15
+
+	// maxMarathonBreakMinutes is the longest allowed break, which is a whole
+	// day as no daily viewing budget is longer than that.
+	maxMarathonBreakMinutes = 24 * 60
+)
+
+func (h *MCUHandler) GetWatchOrder(w http.ResponseWriter, r *http.Request) error {
+	order, filter, err := parseWatchOrderQuery(r)
+	if err != nil {
+		return err
+	}
+
+	movies, err := h.movies.GetMovies(r.Context())
+	if err != nil {
+		return err
+	}
+
+	movies = movies.Filter(filter).WatchOrder(order)
+
+	setResultCount(r, len(movies))
+	h.setFreshness(w)
+	respondJSON(w, http.StatusOK, movies)
+	return nil
+}
+
+func (h *MCUHandler) PlanMarathon(w http.ResponseWriter, r *http.Request) error {
+	order, filter, err := parseWatchOrderQuery(r)
+	if err != nil {
+		return err
+	}
+
+	plan, err := parseMarathonPlan(r)
+	if err != nil {
+		return err
+	}
+
+	format, err := negotiateFormat(r, map[string]string{"ics": "text/calendar"})
+	if err != nil {
+		return err
+	}
+
+	movies, err := h.movies.GetMovies(r.Context())
+	if err != nil {
+		return err
+	}
+
+	marathon, err := movies.Filter(filter).WatchOrder(order).PlanMarathon(plan)
+	if err != nil {
+		return err
+	}
+
+	setResultCount(r, marathon.TotalMovies)
+	h.setFreshness(w)
+
+	if format == "ics" {
+		respondICal(w, marathon)
+		return nil
+	}
+
+	respondJSON(w, http.StatusOK, marathon)
+	return nil
+}
+
+func parseWatchOrderQuery(r *http.Request) (domain.WatchOrder, domain.MovieFilter, error) {
+	q := r.URL.Query()
+
+	order := domain.WatchOrder(q.Get("order"))
+	switch order {
+	case "":
+		order = domain.WatchOrderChronological
+	case domain.WatchOrderChronological, domain.WatchOrderRelease:
+	default:
+		return "", domain.MovieFilter{}, invalidParam("order", "Invalid order %q, must be chronological or release", order)
+	}
+
+	filter := domain.MovieFilter{
+		Saga: q.Get("saga"),
+	}
+
+	ints := []struct {
+		param string
+		dst   *int
+	}{
+		{"phase_from", &filter.PhaseFrom},
+		{"phase_to", &filter.PhaseTo},
+	}
+	for _, i := range ints {
+		v := q.Get(i.param)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return "", domain.MovieFilter{}, invalidParam(i.param, "Invalid %s, must be a positive integer", i.param)
+		}
+		*i.dst = n
+	}
+
+	if filter.PhaseFrom != 0 && filter.PhaseTo != 0 && filter.PhaseFrom > filter.PhaseTo {
+		return "", domain.MovieFilter{}, invalidParam("phase_from", "Invalid phase range, phase_from must not be after phase_to")
+	}
+
+	return order, filter, nil
+}
+
+func parseMarathonPlan(r *http.Request) (domain.MarathonPlan, error) {
+	q := r.URL.Query()
+
+	start, err := time.Parse(time.RFC3339, q.Get("start"))
+	if err != nil {
+		return domain.MarathonPlan{}, invalidParam("start", "Invalid start, must be an RFC 3339 timestamp")
+	}
+
+	hours := float64(defaultMarathonHoursPerDay)
+	if v := q.Get("hours_per_day"); v != "" {
+		hours, err = strconv.ParseFloat(v, 64)
+		if err != nil || hours <= 0 || hours > 24 {
+			return domain.MarathonPlan{}, invalidParam("hours_per_day", "Invalid hours_per_day, must be greater than 0 and at most 24")
+		}
+	}
+
+	breakMinutes := defaultMarathonBreakMinutes
+	if v := q.Get("break_minutes"); v != "" {
+		breakMinutes, err = strconv.Atoi(v)
+		if err != nil || breakMinutes < 0 || breakMinutes > maxMarathonBreakMinutes {
+			return domain.MarathonPlan{}, invalidParam("break_minutes", "Invalid break_minutes, must be between 0 and %d", maxMarathonBreakMinutes)
+		}
+	}
+
+	return domain.MarathonPlan{
+		Start:        start,
+		DailyViewing: time.Duration(hours * float64(time.Hour)),
+		Break:        time.Duration(breakMinutes) * time.Minute,
+	}, nil
+}
@@ -0,0 +1,267 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+var (
+	watchMovieA = &domain.Movie{
+		ID:              1,
+		Title:           "Hello World 1",
+		ReleaseDate:     domain.MustParseDate("2010-01-01"),
+		DurationMinutes: 120,
+		Phase:           1,
+		Saga:            "Epilogue",
+		Chronology:      2,
+	}
+	watchMovieB = &domain.Movie{
+		ID:              2,
+		Title:           "Hello World 2",
+		ReleaseDate:     domain.MustParseDate("2012-01-01"),
+		DurationMinutes: 150,
+		Phase:           2,
+		Saga:            "Epilogue",
+		Chronology:      1,
+	}
+	watchMovieC = &domain.Movie{
+		ID:              3,
+		Title:           "Hello World 3",
+		ReleaseDate:     domain.MustParseDate("2008-01-01"),
+		DurationMinutes: 100,
+		Phase:           3,
+		Saga:            "Finale",
+		Chronology:      3,
+	}
+)
+
+func TestGetWatchOrder(t *testing.T) {
+	tt := []struct {
+		Name           string
+		QueryParams    string
+		ExpectedStatus int
+		ExpectedBody   domain.Movies
+	}{
+		{
+			Name:           "Returns status 200 in chronological order by default",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{watchMovieB, watchMovieA, watchMovieC},
+		},
+		{
+			Name:           "Returns status 200 in release order",
+			QueryParams:    "?order=release",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{watchMovieC, watchMovieA, watchMovieB},
+		},
+		{
+			Name:           "Returns status 200 limited to a saga",
+			QueryParams:    "?saga=epilogue&order=release",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{watchMovieA, watchMovieB},
+		},
+		{
+			Name:           "Returns status 200 limited to a phase range",
+			QueryParams:    "?phase_from=2&phase_to=3",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Movies{watchMovieB, watchMovieC},
+		},
+		{
+			Name:           "Returns status 400 when order is invalid",
+			QueryParams:    "?order=random",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when phase range is inverted",
+			QueryParams:    "?phase_from=3&phase_to=1",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMoviesReturns(domain.Movies{watchMovieA, watchMovieB, watchMovieC}, nil)
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", "/watch-order"+tc.QueryParams, nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if tc.ExpectedBody != nil {
+				var res domain.Movies
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, tc.ExpectedBody, res)
+			}
+		})
+	}
+}
+
+func TestPlanMarathon(t *testing.T) {
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		assert.NoError(t, err)
+		return ts
+	}
+
+	tt := []struct {
+		Name           string
+		QueryParams    string
+		Accept         string
+		ExpectedStatus int
+		ExpectedBody   *domain.Marathon
+		ExpectedEvents int
+	}{
+		{
+			Name:           "Returns status 200 with a day-by-day schedule",
+			QueryParams:    "?start=2024-01-06T10:00:00Z&hours_per_day=4.5&break_minutes=30",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: &domain.Marathon{
+				Start:        at("2024-01-06T10:00:00Z"),
+				End:          at("2024-01-07T14:10:00Z"),
+				TotalMovies:  3,
+				TotalMinutes: 370,
+				Days: []*domain.MarathonDay{
+					{
+						Day:          1,
+						Date:         "2024-01-06",
+						WatchMinutes: 150,
+						Sessions: []*domain.MarathonSession{
+							{Movie: watchMovieB, Start: at("2024-01-06T10:00:00Z"), End: at("2024-01-06T12:30:00Z")},
+						},
+					},
+					{
+						Day:          2,
+						Date:         "2024-01-07",
+						WatchMinutes: 220,
+						Sessions: []*domain.MarathonSession{
+							{Movie: watchMovieA, Start: at("2024-01-07T10:00:00Z"), End: at("2024-01-07T12:00:00Z")},
+							{Movie: watchMovieC, Start: at("2024-01-07T12:30:00Z"), End: at("2024-01-07T14:10:00Z")},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:           "Returns status 200 with an iCalendar export",
+			QueryParams:    "?start=2024-01-06T10:00:00Z&format=ics",
+			ExpectedStatus: http.StatusOK,
+			ExpectedEvents: 3,
+		},
+		{
+			Name:           "Returns status 200 with an iCalendar export when requested by Accept header",
+			QueryParams:    "?start=2024-01-06T10:00:00Z&saga=finale",
+			Accept:         "text/calendar",
+			ExpectedStatus: http.StatusOK,
+			ExpectedEvents: 1,
+		},
+		{
+			Name:           "Returns status 400 when start is missing",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when hours per day is invalid",
+			QueryParams:    "?start=2024-01-06T10:00:00Z&hours_per_day=25",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when break minutes is too long",
+			QueryParams:    "?start=2024-01-06T10:00:00Z&break_minutes=150000000",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when format is invalid",
+			QueryParams:    "?start=2024-01-06T10:00:00Z&format=pdf",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMoviesReturns(domain.Movies{watchMovieA, watchMovieB, watchMovieC}, nil)
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", "/watch-order/marathon"+tc.QueryParams, nil)
+			assert.NoError(t, err)
+			if tc.Accept != "" {
+				req.Header.Set("Accept", tc.Accept)
+			}
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if tc.ExpectedBody != nil {
+				var res *domain.Marathon
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, tc.ExpectedBody, res)
+			}
+
+			if tc.ExpectedEvents > 0 {
+				body := rw.Body.String()
+				assert.Equal(t, "text/calendar; charset=utf-8", rw.Header().Get("Content-Type"))
+				assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\n"))
+				assert.Equal(t, tc.ExpectedEvents, strings.Count(body, "BEGIN:VEVENT\r\n"))
+				for _, line := range strings.Split(body, "\r\n") {
+					assert.LessOrEqual(t, len(line), 75)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanMarathonLongDays(t *testing.T) {
+	service := new(domainfakes.FakeMoviesService)
+	service.GetMoviesReturns(domain.Movies{
+		{ID: 1, Chronology: 1, DurationMinutes: 660},
+		{ID: 2, Chronology: 2, DurationMinutes: 690},
+		{ID: 3, Chronology: 3, DurationMinutes: 100},
+	}, nil)
+
+	router := mux.NewRouter()
+	handler := server.NewMCUHandler(service)
+	handler.RegisterRoutes(router)
+
+	req, err := http.NewRequest("GET", "/watch-order/marathon?start=2024-01-06T10:00:00Z&hours_per_day=24&break_minutes=60", nil)
+	assert.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	var res *domain.Marathon
+	assert.NoError(t, json.NewDecoder(rw.Body).Decode(&res))
+
+	var sessions []*domain.MarathonSession
+	for _, day := range res.Days {
+		sessions = append(sessions, day.Sessions...)
+	}
+	if assert.Len(t, res.Days, 2) && assert.Len(t, sessions, 3) {
+		assert.Equal(t, "2024-01-07T10:30:00Z", res.Days[1].Sessions[0].Start.Format(time.RFC3339))
+		for i := 1; i < len(sessions); i++ {
+			assert.False(t, sessions[i].Start.Before(sessions[i-1].End.Add(time.Hour)), "session %d starts before the break after session %d is over", i+1, i)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	handlerRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_server_requests_total",
+		Help: "Requests handled by the server, by route template and status code.",
+	}, []string{"route", "method", "status"})
+
+	handlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_server_request_duration_seconds",
+		Help:    "Latency of requests handled by the server, by route template.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	handlerInflight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_server_requests_in_flight",
+		Help: "Requests currently being handled by the server, by route template.",
+	}, []string{"route"})
+)
+
+// InstrumentRoutes is a mux middleware that records request counts, latencies
+// and in-flight requests labelled by the matched route's path template.
+func InstrumentRoutes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+
+		inflight := handlerInflight.WithLabelValues(route)
+		inflight.Inc()
+		defer inflight.Dec()
+
+		m := httpsnoop.CaptureMetrics(next, w, r)
+		handlerRequests.WithLabelValues(route, r.Method, strconv.Itoa(m.Code)).Inc()
+		handlerDuration.WithLabelValues(route, r.Method).Observe(m.Duration.Seconds())
+	})
+}
+
+// HandleUnmatched responds to requests that match no route of r through the
+// given middlewares, which mux otherwise only applies to matched routes, so
+// that they're traced and measured under the "unmatched" route.
+func HandleUnmatched(r *mux.Router, mwf ...mux.MiddlewareFunc) {
+	var notFound, methodNotAllowed http.Handler = http.NotFoundHandler(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+	for i := len(mwf) - 1; i >= 0; i-- {
+		notFound = mwf[i](notFound)
+		methodNotAllowed = mwf[i](methodNotAllowed)
+	}
+
+	r.NotFoundHandler = notFound
+	r.MethodNotAllowedHandler = methodNotAllowed
+}
+
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "unmatched"
+	}
+
+	tmpl, err := route.GetPathTemplate()
+	if err != nil {
+		return "unmatched"
+	}
+	return tmpl
+}
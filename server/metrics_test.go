@@ -0,0 +1,89 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestInstrumentRoutes(t *testing.T) {
+	service := new(domainfakes.FakeMoviesService)
+	service.GetMovieReturns(nil, domain.ErrMovieNotFound)
+
+	router := mux.NewRouter()
+	router.Use(server.InstrumentRoutes)
+	handler := server.NewMCUHandler(service)
+	handler.RegisterRoutes(router.PathPrefix("/api/v1/mcu").Subrouter())
+
+	for _, id := range []string{"1", "2"} {
+		req, err := http.NewRequest("GET", "/api/v1/mcu/movies/"+id, nil)
+		assert.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+	}
+
+	assert.Equal(t, float64(2), requestCount(t, "/api/v1/mcu/movies/{id}", "404"))
+}
+
+func TestInstrumentRoutesUnmatched(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(server.InstrumentRoutes)
+	server.HandleUnmatched(router, server.InstrumentRoutes)
+	server.NewMCUHandler(new(domainfakes.FakeMoviesService)).RegisterRoutes(router.PathPrefix("/api/v1/mcu").Subrouter())
+
+	notFound := requestCount(t, "unmatched", "404")
+	methodNotAllowed := requestCount(t, "unmatched", "405")
+
+	for _, tc := range []struct {
+		Method         string
+		Target         string
+		ExpectedStatus int
+	}{
+		{Method: "GET", Target: "/api/v1/mcu/nope", ExpectedStatus: http.StatusNotFound},
+		{Method: "POST", Target: "/api/v1/mcu/movies", ExpectedStatus: http.StatusMethodNotAllowed},
+	} {
+		req, err := http.NewRequest(tc.Method, tc.Target, nil)
+		assert.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, tc.ExpectedStatus, rw.Code)
+	}
+
+	assert.Equal(t, notFound+1, requestCount(t, "unmatched", "404"))
+	assert.Equal(t, methodNotAllowed+1, requestCount(t, "unmatched", "405"))
+}
+
+// requestCount returns the number of requests counted for route with status.
+func requestCount(t *testing.T, route, status string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+
+	var count float64
+	for _, family := range families {
+		if family.GetName() != "http_server_requests_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["route"] == route && labels["status"] == status {
+				count += metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,398 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+// operation documents a named route for the OpenAPI document.
+type operation struct {
+	summary string
+	tag     string
+	// params are the query parameters accepted. Path parameters are derived
+	// from the route.
+	params []*openapi3.Parameter
+	// body is a value of the JSON request body's type, if any.
+	body interface{}
+	// bodyRequired is set when a request body must be sent.
+	bodyRequired bool
+	// bodyRules adds constraints the body's type can't express.
+	bodyRules func(schema *openapi3.Schema)
+	// status is the status of successful responses, defaulting to 200.
+	status int
+	// created is set when 201 is returned instead if a resource was created.
+	created bool
+	// response is a value of the JSON response body's type, or nil if
+	// successful responses have no content.
+	response interface{}
+	// formats are the media types offered besides JSON.
+	formats []string
+	// admin is set when the admin token must be sent.
+	admin bool
+}
+
+const adminSecurityScheme = "adminToken"
+
+// pathParams describes the variables used in route paths.
+var pathParams = map[string]*openapi3.Parameter{
+	"id":     openapi3.NewPathParameter("id").WithDescription("ID of the movie.").WithSchema(openapi3.NewIntegerSchema()),
+	"saga":   openapi3.NewPathParameter("saga").WithDescription("Name or slug of the saga.").WithSchema(openapi3.NewStringSchema()),
+	"number": openapi3.NewPathParameter("number").WithDescription("Number of the phase.").WithSchema(openapi3.NewIntegerSchema()),
+	"user":   openapi3.NewPathParameter("user").WithDescription("ID of the user.").WithSchema(openapi3.NewStringSchema()),
+}
+
+// undocumentedRoutes names the routes under /api/v1 that aren't REST
+// operations, and so are left out of the OpenAPI document.
+var undocumentedRoutes = map[string]bool{
+	"graphql": true,
+}
+
+// NewOpenAPI builds an OpenAPI document of the named routes of router. Every
+// route under /api/v1 must be named, and every named route must have a
+// documented operation, so the document can't fall out of step with the routes
+// that are served.
+func NewOpenAPI(router *mux.Router) (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   "Simple API",
+			Version: "v1",
+		},
+		Paths: openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{},
+			SecuritySchemes: openapi3.SecuritySchemes{
+				adminSecurityScheme: &openapi3.SecuritySchemeRef{
+					Value: openapi3.NewSecurityScheme().WithType("http").WithScheme("bearer").WithDescription("The admin token the server was started with."),
+				},
+			},
+		},
+	}
+
+	s := newSchemas(doc.Components.Schemas)
+	problemRef := s.ref(reflect.TypeOf(Problem{}))
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		// Routes without a handler only lead to subrouters.
+		if route.GetHandler() == nil {
+			return nil
+		}
+
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		name := route.GetName()
+		switch {
+		case undocumentedRoutes[name]:
+			return nil
+		case name == "" && strings.HasPrefix(path, "/api/v1/"):
+			return fmt.Errorf("route %q has no name to document it under", path)
+		case name == "":
+			return nil
+		}
+
+		op, ok := operations[name]
+		if !ok {
+			return fmt.Errorf("no operation documented for route %q", name)
+		}
+
+		method, err := routeMethod(route)
+		if err != nil {
+			return fmt.Errorf("route %q: %w", name, err)
+		}
+
+		o := openapi3.NewOperation()
+		o.OperationID = name
+		o.Summary = op.summary
+		o.Tags = []string{op.tag}
+		o.Parameters = openapi3.Parameters{}
+		if op.admin {
+			o.Security = openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate(adminSecurityScheme))
+		}
+
+		vars, err := pathVars(path)
+		if err != nil {
+			return fmt.Errorf("route %q: %w", name, err)
+		}
+		for _, v := range vars {
+			param, ok := pathParams[v]
+			if !ok {
+				return fmt.Errorf("route %q: no documented path parameter %q", name, v)
+			}
+			o.AddParameter(param)
+		}
+		for _, param := range op.params {
+			o.AddParameter(param)
+		}
+
+		if op.body != nil {
+			ref := s.ref(reflect.TypeOf(op.body))
+			if op.bodyRules != nil {
+				op.bodyRules(doc.Components.Schemas[strings.TrimPrefix(ref.Ref, "#/components/schemas/")].Value)
+			}
+			body := openapi3.NewRequestBody().WithJSONSchemaRef(ref)
+			body.Required = op.bodyRequired
+			o.RequestBody = &openapi3.RequestBodyRef{Value: body}
+		}
+
+		status := op.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		res := openapi3.NewResponse().WithDescription(http.StatusText(status))
+		if op.response != nil {
+			res.WithJSONSchemaRef(s.ref(reflect.TypeOf(op.response)))
+		}
+		for _, mediaType := range op.formats {
+			schema := openapi3.NewStringSchema()
+			if !strings.HasPrefix(mediaType, "text/") {
+				schema.WithFormat("binary")
+			}
+			if res.Content == nil {
+				res.Content = openapi3.Content{}
+			}
+			res.Content[mediaType] = openapi3.NewMediaType().WithSchema(schema)
+		}
+		o.AddResponse(status, res)
+		if op.created {
+			o.AddResponse(http.StatusCreated, &openapi3.Response{
+				Description: res.Description,
+				Content:     res.Content,
+			})
+		}
+		o.Responses.Set("default", &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().WithDescription("Error").WithContent(openapi3.Content{
+				problemContentType: openapi3.NewMediaType().WithSchemaRef(problemRef),
+			}),
+		})
+
+		doc.AddOperation(path, method, o)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// routeMethod returns the method a route is documented under. Routes that
+// don't restrict their methods are documented as GET, and HEAD is implied.
+func routeMethod(route *mux.Route) (string, error) {
+	methods, err := route.GetMethods()
+	if err != nil {
+		return http.MethodGet, nil
+	}
+
+	var documented []string
+	for _, method := range methods {
+		if method != http.MethodHead {
+			documented = append(documented, method)
+		}
+	}
+	if len(documented) != 1 {
+		return "", fmt.Errorf("documented routes must have a single method, got %v", methods)
+	}
+	return documented[0], nil
+}
+
+func pathVars(template string) ([]string, error) {
+	var vars []string
+	for _, segment := range strings.Split(template, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name, _, _ := strings.Cut(segment[1:len(segment)-1], ":")
+			vars = append(vars, name)
+		} else if strings.ContainsAny(segment, "{}") {
+			return nil, fmt.Errorf("unsupported path segment %q", segment)
+		}
+	}
+	return vars, nil
+}
+
+var (
+	dateType       = reflect.TypeOf(domain.Date{})
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// enums lists the values of string types with a fixed set of values.
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(domain.ModerationStatus("")): {
+		string(domain.ModerationPublished),
+		string(domain.ModerationFlagged),
+		string(domain.ModerationHidden),
+	},
+	reflect.TypeOf(ErrorCode("")): errorCodes(),
+	reflect.TypeOf(domain.DataQualityProblem("")): {
+		string(domain.DataQualityMissing),
+		string(domain.DataQualityUnparsable),
+		string(domain.DataQualityUnknown),
+		string(domain.DataQualityInvalid),
+	},
+	reflect.TypeOf(domain.ChangeType("")): {
+		string(domain.ChangeAdded),
+		string(domain.ChangeRemoved),
+		string(domain.ChangeChanged),
+	},
+}
+
+// schemas derives JSON schemas from Go types, naming properties by their
+// json tag. Structs become component schemas, and embedded structs are
+// flattened like they are in JSON.
+type schemas struct {
+	components openapi3.Schemas
+	types      map[string]reflect.Type
+}
+
+func newSchemas(components openapi3.Schemas) *schemas {
+	return &schemas{
+		components: components,
+		types:      make(map[string]reflect.Type),
+	}
+}
+
+func (s *schemas) ref(t reflect.Type) *openapi3.SchemaRef {
+	switch t {
+	case dateType:
+		schema := openapi3.NewStringSchema().WithFormat("date").WithNullable()
+		schema.Description = "Null if the date hasn't been announced."
+		return schema.NewRef()
+	case timeType:
+		return openapi3.NewDateTimeSchema().NewRef()
+	case rawMessageType:
+		return openapi3.NewSchema().NewRef()
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if t.Elem().Kind() == reflect.Struct && t.Elem() != timeType {
+			return s.ref(t.Elem())
+		}
+		ref := s.ref(t.Elem())
+		schema := *ref.Value
+		schema.Nullable = true
+		return schema.NewRef()
+	case reflect.String:
+		schema := openapi3.NewStringSchema()
+		if values, ok := enums[t]; ok {
+			for _, v := range values {
+				schema.Enum = append(schema.Enum, v)
+			}
+		}
+		return schema.NewRef()
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return openapi3.NewIntegerSchema().NewRef()
+	case reflect.Float32, reflect.Float64:
+		return openapi3.NewFloat64Schema().NewRef()
+	case reflect.Bool:
+		return openapi3.NewBoolSchema().NewRef()
+	case reflect.Slice:
+		schema := openapi3.NewArraySchema()
+		schema.Items = s.ref(t.Elem())
+		return schema.NewRef()
+	case reflect.Map:
+		schema := openapi3.NewObjectSchema()
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: s.ref(t.Elem())}
+		return schema.NewRef()
+	case reflect.Struct:
+		return s.component(t)
+	}
+
+	return openapi3.NewSchema().NewRef()
+}
+
+// component returns a reference to the component schema for the struct t,
+// adding it if needed.
+func (s *schemas) component(t reflect.Type) *openapi3.SchemaRef {
+	name := componentName(t)
+	if existing, ok := s.types[name]; ok && existing != t {
+		// Types of the same name from different packages are told apart by
+		// their package.
+		name = capitalize(path.Base(t.PkgPath())) + name
+	}
+
+	if component, ok := s.components[name]; ok {
+		return openapi3.NewSchemaRef("#/components/schemas/"+name, component.Value)
+	}
+	s.types[name] = t
+
+	schema := openapi3.NewObjectSchema()
+	s.components[name] = schema.NewRef()
+	s.addProperties(schema, t)
+
+	return openapi3.NewSchemaRef("#/components/schemas/"+name, schema)
+}
+
+func (s *schemas) addProperties(schema *openapi3.Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			s.addProperties(schema, sf.Type)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		schema.WithPropertyRef(name, s.ref(sf.Type))
+	}
+}
+
+func componentName(t reflect.Type) string {
+	return capitalize(t.Name())
+}
+
+func capitalize(s string) string {
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+	return string(r)
+}
+
+// errorCodes returns the codes in the catalogue of problems, sorted.
+func errorCodes() []string {
+	codes := make([]string, 0, len(problemTypes))
+	for code := range problemTypes {
+		codes = append(codes, string(code))
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// enumValues returns the keys of a set, sorted, for use as an enum.
+func enumValues(set map[string]bool) []interface{} {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, k)
+	}
+	return values
+}
@@ -0,0 +1,329 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/recommend"
+	"github.com/jace-ys/simple-api/search"
+)
+
+func queryParam(name, description string, schema *openapi3.Schema) *openapi3.Parameter {
+	return openapi3.NewQueryParameter(name).WithDescription(description).WithSchema(schema)
+}
+
+// listParam documents a comma-separated query parameter.
+func listParam(name, description string, items *openapi3.Schema) *openapi3.Parameter {
+	explode := false
+	param := queryParam(name, description, openapi3.NewArraySchema().WithItems(items))
+	param.Style = openapi3.SerializationForm
+	param.Explode = &explode
+	return param
+}
+
+func formatParam(formats map[string]string) *openapi3.Parameter {
+	values := map[string]bool{formatJSON: true}
+	for format := range formats {
+		values[format] = true
+	}
+	return queryParam("format", "Format of the response, overriding the Accept header.", openapi3.NewStringSchema().WithEnum(enumValues(values)...))
+}
+
+func formatMediaTypes(formats map[string]string) []string {
+	var mediaTypes []string
+	for _, mediaType := range formats {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	return mediaTypes
+}
+
+func nonNegativeInt() *openapi3.Schema {
+	return openapi3.NewIntegerSchema().WithMin(0)
+}
+
+func intBetween(min, max int) *openapi3.Schema {
+	return openapi3.NewIntegerSchema().WithMin(float64(min)).WithMax(float64(max))
+}
+
+func requireProperties(names ...string) func(schema *openapi3.Schema) {
+	return func(schema *openapi3.Schema) {
+		schema.Required = append(schema.Required, names...)
+	}
+}
+
+var (
+	fieldsParam = listParam("fields", "Movie fields to include, defaulting to all of them.", openapi3.NewStringSchema().WithEnum(enumValues(movieFields)...))
+	sagaParam   = queryParam("saga", "Only include movies in the saga, by name or slug.", openapi3.NewStringSchema())
+	orderParam  = queryParam("order", "Sort order.", openapi3.NewStringSchema().WithEnum(string(domain.SortAsc), string(domain.SortDesc)))
+
+	watchOrderParams = []*openapi3.Parameter{
+		queryParam("order", "Order to watch movies in.", openapi3.NewStringSchema().WithEnum(string(domain.WatchOrderChronological), string(domain.WatchOrderRelease))),
+		sagaParam,
+		queryParam("phase_from", "Only include movies from this phase onwards.", openapi3.NewIntegerSchema().WithMin(1)),
+		queryParam("phase_to", "Only include movies up to this phase.", openapi3.NewIntegerSchema().WithMin(1)),
+	}
+
+	marathonFormats = map[string]string{"ics": "text/calendar"}
+)
+
+// operations documents the named routes served under /api/v1, keyed by route
+// name, which doubles as the operation ID.
+var operations = map[string]operation{
+	"listMovies": {
+		summary: "List movies, optionally filtered, sorted and paginated.",
+		tag:     "movies",
+		params: []*openapi3.Parameter{
+			fieldsParam,
+			sagaParam,
+			listParam("phase", "Only include movies in any of the phases.", openapi3.NewIntegerSchema()),
+			queryParam("director", "Only include movies with a director whose name contains this.", openapi3.NewStringSchema()),
+			queryParam("released_from", "Only include movies released in or after this year.", nonNegativeInt()),
+			queryParam("released_to", "Only include movies released in or before this year.", nonNegativeInt()),
+			queryParam("min_box_office", "Only include movies that grossed at least this much.", nonNegativeInt()),
+			queryParam("post_credit_scenes", "Only include movies with, or without, post-credit scenes.", openapi3.NewBoolSchema()),
+			queryParam("sort_by", "Field to sort movies by.", openapi3.NewStringSchema().WithEnum(
+				string(domain.MovieSortReleaseDate),
+				string(domain.MovieSortChronology),
+				string(domain.MovieSortBoxOffice),
+				string(domain.MovieSortDuration),
+				string(domain.MovieSortTitle),
+			)),
+			orderParam,
+			queryParam("limit", "Maximum number of movies to return, or zero for all of them.", intBetween(0, maxMoviesLimit)),
+			queryParam("offset", "Number of movies to skip.", nonNegativeInt()),
+		},
+		response: domain.Movies{},
+	},
+	"searchMovies": {
+		summary: "Search movies by title, overview and director.",
+		tag:     "movies",
+		params: []*openapi3.Parameter{
+			queryParam("q", "Search query.", openapi3.NewStringSchema()).WithRequired(true),
+			queryParam("limit", "Maximum number of results to return.", intBetween(1, maxSearchLimit)),
+		},
+		response: []*search.Result{},
+	},
+	"getMovie": {
+		summary:  "Get a movie.",
+		tag:      "movies",
+		params:   []*openapi3.Parameter{fieldsParam},
+		response: domain.Movie{},
+	},
+	"listUpcoming": {
+		summary:  "List movies that haven't been released yet.",
+		tag:      "movies",
+		response: []*domain.UpcomingRelease{},
+	},
+	"getCover": {
+		summary: "Get a movie's cover image.",
+		tag:     "movies",
+		params: []*openapi3.Parameter{
+			queryParam("size", "Size of the image.", openapi3.NewStringSchema().WithEnum(
+				string(domain.CoverSizeOriginal),
+				string(domain.CoverSizeMedium),
+				string(domain.CoverSizeThumb),
+			)),
+		},
+		formats: []string{"image/*"},
+	},
+	"getChanges": {
+		summary: "List changes made to the catalog since a version.",
+		tag:     "movies",
+		params: []*openapi3.Parameter{
+			queryParam("since", "Catalog version to list changes since, defaulting to every change retained.", nonNegativeInt()),
+		},
+		response: domain.CatalogChanges{},
+	},
+	"listSagas": {
+		summary: "List sagas with their phases and movies.",
+		tag:     "sagas",
+		params: []*openapi3.Parameter{
+			queryParam("name", "Return only the saga with this name or slug, as a single object.", openapi3.NewStringSchema()),
+		},
+		response: domain.Sagas{},
+	},
+	"getSaga": {
+		summary:  "Get a saga.",
+		tag:      "sagas",
+		response: domain.Saga{},
+	},
+	"listSagaPhases": {
+		summary:  "List the phases of a saga.",
+		tag:      "sagas",
+		response: domain.Phases{},
+	},
+	"getSagaPhase": {
+		summary:  "Get a phase of a saga.",
+		tag:      "sagas",
+		response: domain.Phase{},
+	},
+	"getStats": {
+		summary:  "Get every box office statistic.",
+		tag:      "stats",
+		response: statsOverview{},
+	},
+	"getBoxOfficeByYear": {
+		summary:  "Get box office totals by year.",
+		tag:      "stats",
+		params:   []*openapi3.Parameter{formatParam(csvFormats)},
+		response: []*domain.YearlyBoxOffice{},
+		formats:  formatMediaTypes(csvFormats),
+	},
+	"getCumulativeBoxOffice": {
+		summary:  "Get the cumulative box office in release order.",
+		tag:      "stats",
+		params:   []*openapi3.Parameter{formatParam(csvFormats)},
+		response: []*domain.CumulativeBoxOffice{},
+		formats:  formatMediaTypes(csvFormats),
+	},
+	"getBoxOfficePerMinute": {
+		summary:  "Rank movies by box office per minute of runtime.",
+		tag:      "stats",
+		params:   []*openapi3.Parameter{formatParam(csvFormats)},
+		response: []*domain.BoxOfficePerMinute{},
+		formats:  formatMediaTypes(csvFormats),
+	},
+	"getTopGrossingByPhase": {
+		summary: "Get the top grossing movies of each phase.",
+		tag:     "stats",
+		params: []*openapi3.Parameter{
+			queryParam("n", "Number of movies per phase.", intBetween(1, maxTopGrossing)),
+			formatParam(csvFormats),
+		},
+		response: []*domain.PhaseTopGrossing{},
+		formats:  formatMediaTypes(csvFormats),
+	},
+	"getSagaYearOverYear": {
+		summary:  "Compare each saga's box office year over year.",
+		tag:      "stats",
+		params:   []*openapi3.Parameter{formatParam(csvFormats)},
+		response: []*domain.SagaYearOverYear{},
+		formats:  formatMediaTypes(csvFormats),
+	},
+	"getWatchOrder": {
+		summary:  "List movies in the order to watch them.",
+		tag:      "watch order",
+		params:   watchOrderParams,
+		response: domain.Movies{},
+	},
+	"planMarathon": {
+		summary: "Schedule a marathon of movies in watch order.",
+		tag:     "watch order",
+		params: append(append([]*openapi3.Parameter{}, watchOrderParams...),
+			queryParam("start", "When the marathon starts.", openapi3.NewDateTimeSchema()).WithRequired(true),
+			queryParam("hours_per_day", "Hours of viewing per day, counting breaks.", openapi3.NewFloat64Schema().WithMin(0).WithExclusiveMin(true).WithMax(24)),
+			queryParam("break_minutes", "Minutes of break between movies.", intBetween(0, maxMarathonBreakMinutes)),
+			formatParam(marathonFormats),
+		),
+		response: domain.Marathon{},
+		formats:  formatMediaTypes(marathonFormats),
+	},
+	"getRecommendations": {
+		summary: "Recommend movies similar to a movie, a list of watched movies, or a user's watched movies.",
+		tag:     "recommendations",
+		params: []*openapi3.Parameter{
+			queryParam("movie_id", "Recommend movies similar to this one.", openapi3.NewIntegerSchema()),
+			listParam("watched", "Recommend movies similar to these.", openapi3.NewIntegerSchema()),
+			queryParam("user", "Recommend movies similar to those the user has watched.", openapi3.NewStringSchema()),
+			queryParam("limit", "Maximum number of recommendations.", intBetween(1, maxRecommendationsLimit)),
+		},
+		response: []*recommend.Recommendation{},
+	},
+	"listReviews": {
+		summary:  "List the published reviews of a movie.",
+		tag:      "reviews",
+		response: []*domain.Review{},
+	},
+	"getReview": {
+		summary:  "Get a user's review of a movie.",
+		tag:      "reviews",
+		response: domain.Review{},
+	},
+	"putReview": {
+		summary:      "Create or update a user's review of a movie.",
+		tag:          "reviews",
+		body:         PutReviewRequest{},
+		bodyRequired: true,
+		bodyRules: func(schema *openapi3.Schema) {
+			schema.Required = []string{"rating"}
+			schema.Properties["rating"].Value.WithMin(domain.MinRating).WithMax(domain.MaxRating)
+			schema.Properties["text"].Value.WithMaxLength(domain.MaxReviewTextLength)
+		},
+		response: domain.Review{},
+		created:  true,
+	},
+	"deleteReview": {
+		summary: "Delete a user's review of a movie.",
+		tag:     "reviews",
+		status:  http.StatusNoContent,
+	},
+	"flagReview": {
+		summary:  "Flag a review for moderation.",
+		tag:      "reviews",
+		response: domain.Review{},
+	},
+	"moderateReview": {
+		summary:      "Set the moderation status of a review.",
+		tag:          "reviews",
+		body:         ModerateReviewRequest{},
+		bodyRequired: true,
+		bodyRules:    requireProperties("moderation"),
+		response:     domain.Review{},
+		admin:        true,
+	},
+	"getWatchlist": {
+		summary:  "Get a user's watchlist.",
+		tag:      "watchlists",
+		response: domain.Watchlist{},
+	},
+	"getWatchlistProgress": {
+		summary:  "Get a user's progress through each saga and phase.",
+		tag:      "watchlists",
+		response: []*domain.SagaProgress{},
+	},
+	"addToWatchlist": {
+		summary:  "Add a movie to a user's watchlist.",
+		tag:      "watchlists",
+		response: domain.WatchlistEntry{},
+	},
+	"removeFromWatchlist": {
+		summary: "Remove a movie from a user's watchlist.",
+		tag:     "watchlists",
+		status:  http.StatusNoContent,
+	},
+	"markWatched": {
+		summary:  "Mark a movie on a user's watchlist as watched, now or at the given time.",
+		tag:      "watchlists",
+		body:     MarkWatchedRequest{},
+		response: domain.WatchlistEntry{},
+	},
+	"markUnwatched": {
+		summary:  "Mark a movie on a user's watchlist as not watched.",
+		tag:      "watchlists",
+		response: domain.WatchlistEntry{},
+	},
+	"searchFlights": {
+		summary: "Search flights across every airline.",
+		tag:     "flights",
+		params: []*openapi3.Parameter{
+			queryParam("sort_by", "Field to sort flights by.", openapi3.NewStringSchema().WithEnum("price", "duration")),
+			orderParam,
+		},
+		body:         SearchFlightsRequest{},
+		bodyRequired: true,
+		bodyRules: func(schema *openapi3.Schema) {
+			schema.Required = []string{"origin", "destination", "departure_date"}
+			schema.Properties["origin"].Value.WithMaxLength(3)
+			schema.Properties["destination"].Value.WithMaxLength(3)
+			schema.Properties["departure_date"].Value.WithFormat("date")
+		},
+		response: domain.DuffelFlights{},
+	},
+	"getDataQuality": {
+		summary:  "Report the problems found in the latest MCU data.",
+		tag:      "admin",
+		response: domain.DataQualityReport{},
+	},
+}
@@ -0,0 +1,226 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/graph"
+	"github.com/jace-ys/simple-api/server"
+)
+
+// newAPIRouter registers every handler served under /api/v1 like main does.
+func newAPIRouter(t *testing.T, movies *domainfakes.FakeMoviesService, airline *domainfakes.FakeFlightsService) (*mux.Router, *mux.Router) {
+	router := mux.NewRouter()
+	router.Handle("/metrics", promhttp.Handler())
+	v1 := router.PathPrefix("/api/v1/").Subrouter()
+
+	mcu := v1.PathPrefix("/mcu").Subrouter()
+	reviews := new(domainfakes.FakeReviewStore)
+	watchlists := new(domainfakes.FakeWatchlistStore)
+	server.NewMCUHandler(movies).WithRatings(reviews).RegisterRoutes(mcu)
+	server.NewReviewsHandler(movies, reviews).RegisterRoutes(mcu)
+	server.NewWatchlistHandler(movies, watchlists).RegisterRoutes(mcu)
+	server.NewRecommendationsHandler(movies, watchlists).RegisterRoutes(mcu)
+	server.NewCoversHandler(movies, new(domainfakes.FakeCoversService)).RegisterRoutes(mcu)
+	server.NewChangesHandler(new(domainfakes.FakeChangeFeed)).RegisterRoutes(mcu)
+
+	admin := v1.PathPrefix("/admin").Subrouter()
+	server.NewDataQualityHandler(new(domainfakes.FakeDataQualityService)).RegisterRoutes(admin)
+	adminMCU := admin.PathPrefix("/mcu").Subrouter()
+	adminMCU.Use(server.RequireAdminToken("s3cret"))
+	server.NewReviewsHandler(movies, reviews).RegisterAdminRoutes(adminMCU)
+	server.NewDuffelFlightsHandler(airline, airline).RegisterRoutes(v1.PathPrefix("/duffel").Subrouter())
+
+	schema, err := graph.NewSchema(movies, []domain.FlightsService{airline}, graph.DefaultLimits())
+	assert.NoError(t, err)
+	server.NewGraphQLHandler(schema).RegisterRoutes(v1)
+
+	return router, v1
+}
+
+func TestNewOpenAPI(t *testing.T) {
+	router, _ := newAPIRouter(t, new(domainfakes.FakeMoviesService), new(domainfakes.FakeFlightsService))
+
+	doc, err := server.NewOpenAPI(router)
+	assert.NoError(t, err)
+	assert.NoError(t, doc.Validate(context.Background()))
+
+	operations := make(map[string]string)
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			operations[method+" "+path] = op.OperationID
+		}
+	}
+
+	err = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil || route.GetHandler() == nil || !strings.HasPrefix(path, "/api/v1/") || path == "/api/v1/graphql" {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{http.MethodGet}
+		}
+		for _, method := range methods {
+			if method == http.MethodHead {
+				continue
+			}
+			assert.Equal(t, route.GetName(), operations[method+" "+path], "%s %s is not documented", method, path)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	op := doc.Paths.Find("/api/v1/mcu/movies/{id}/reviews/{user}").Put
+	assert.Contains(t, op.Responses.Map(), "201")
+	assert.Equal(t, "#/components/schemas/PutReviewRequest", op.RequestBody.Value.Content.Get("application/json").Schema.Ref)
+	assert.Equal(t, "#/components/schemas/Problem", op.Responses.Default().Value.Content.Get("application/problem+json").Schema.Ref)
+	assert.Nil(t, op.Security)
+
+	op = doc.Paths.Find("/api/v1/admin/mcu/movies/{id}/reviews/{user}/moderation").Put
+	assert.Equal(t, &openapi3.SecurityRequirements{{"adminToken": {}}}, op.Security)
+}
+
+func TestNewOpenAPIUndocumentedRoute(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/undocumented", func(w http.ResponseWriter, r *http.Request) {}).Name("undocumented")
+
+	_, err := server.NewOpenAPI(router)
+	assert.EqualError(t, err, `no operation documented for route "undocumented"`)
+}
+
+func TestNewOpenAPIUnnamedRoute(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err := server.NewOpenAPI(router)
+	assert.NoError(t, err, "routes outside /api/v1 needn't be documented")
+
+	router.PathPrefix("/api/v1/").Subrouter().HandleFunc("/unnamed", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err = server.NewOpenAPI(router)
+	assert.EqualError(t, err, `route "/api/v1/unnamed" has no name to document it under`)
+}
+
+func TestValidateRequests(t *testing.T) {
+	tt := []struct {
+		Name           string
+		Method         string
+		Target         string
+		Body           string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		{
+			Name:           "Passes through a valid request",
+			Method:         "GET",
+			Target:         "/api/v1/mcu/movies?phase=1,2&limit=10",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `[]`,
+		},
+		{
+			Name:           "Returns status 400 with issues for invalid query parameters",
+			Method:         "GET",
+			Target:         "/api/v1/mcu/movies?limit=-1&sort_by=rating",
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody: `{"type": "urn:simple-api:problem:invalid_request", "title": "Invalid request", "status": 400, "detail": "Request doesn't match the OpenAPI document", "instance": "/api/v1/mcu/movies", "code": "invalid_request", "issues": [
+				{"in": "query", "name": "sort_by", "message": "value is not one of the allowed values [\"release_date\",\"chronology\",\"box_office\",\"duration\",\"title\"]"},
+				{"in": "query", "name": "limit", "message": "number must be at least 0"}
+			]}`,
+		},
+		{
+			Name:           "Returns status 400 with issues for an invalid path parameter",
+			Method:         "GET",
+			Target:         "/api/v1/mcu/movies/iron-man",
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody: `{"type": "urn:simple-api:problem:invalid_request", "title": "Invalid request", "status": 400, "detail": "Request doesn't match the OpenAPI document", "instance": "/api/v1/mcu/movies/iron-man", "code": "invalid_request", "issues": [
+				{"in": "path", "name": "id", "message": "value iron-man: an invalid integer: invalid syntax"}
+			]}`,
+		},
+		{
+			Name:           "Returns status 400 with issues for an invalid body",
+			Method:         "POST",
+			Target:         "/api/v1/duffel/flights/search",
+			Body:           `{"destination": "JFK", "departure_date": "2021-01-01"}`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody: `{"type": "urn:simple-api:problem:invalid_request", "title": "Invalid request", "status": 400, "detail": "Request doesn't match the OpenAPI document", "instance": "/api/v1/duffel/flights/search", "code": "invalid_request", "issues": [
+				{"in": "body", "name": "origin", "message": "property \"origin\" is missing"}
+			]}`,
+		},
+		{
+			Name:           "Returns status 400 with issues for a missing body",
+			Method:         "PUT",
+			Target:         "/api/v1/mcu/movies/1/reviews/tony",
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody: `{"type": "urn:simple-api:problem:invalid_request", "title": "Invalid request", "status": 400, "detail": "Request doesn't match the OpenAPI document", "instance": "/api/v1/mcu/movies/1/reviews/tony", "code": "invalid_request", "issues": [
+				{"in": "body", "message": "value is required but missing"}
+			]}`,
+		},
+		{
+			Name:           "Passes through requests to undocumented routes",
+			Method:         "GET",
+			Target:         "/api/v1/undocumented?limit=-1",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			movies := new(domainfakes.FakeMoviesService)
+			movies.GetMoviesReturns(domain.Movies{}, nil)
+
+			router, v1 := newAPIRouter(t, movies, new(domainfakes.FakeFlightsService))
+			doc, err := server.NewOpenAPI(router)
+			assert.NoError(t, err)
+			v1.Use(server.ValidateRequests(doc))
+			v1.HandleFunc("/undocumented", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{}`))
+			})
+
+			req, err := http.NewRequest(tc.Method, tc.Target, strings.NewReader(tc.Body))
+			assert.NoError(t, err)
+			if tc.Body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			var body json.RawMessage
+			assert.NoError(t, json.NewDecoder(rw.Body).Decode(&body))
+			assert.JSONEq(t, tc.ExpectedBody, string(body))
+		})
+	}
+}
+
+func TestDocs(t *testing.T) {
+	doc := &openapi3.T{OpenAPI: "3.0.3", Info: &openapi3.Info{Title: "Simple API", Version: "v1"}}
+
+	router := mux.NewRouter()
+	server.NewDocsHandler(doc).RegisterRoutes(router)
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest("GET", "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `{"openapi": "3.0.3", "info": {"title": "Simple API", "version": "v1"}, "paths": null}`, rw.Body.String())
+
+	rw = httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest("GET", "/docs", nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Body.String(), `url: "/openapi.json"`)
+}
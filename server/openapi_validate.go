@@ -0,0 +1,127 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gorilla/mux"
+)
+
+// ValidateRequests returns middleware that checks requests against the
+// operations in doc, responding with an invalid_request problem listing every
+// issue found. Requests to routes that aren't documented are passed through.
+func ValidateRequests(doc *openapi3.T) mux.MiddlewareFunc {
+	routes := make(map[string]*routers.Route)
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			routes[op.OperationID] = &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: op,
+			}
+		}
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var route *routers.Route
+			if current := mux.CurrentRoute(r); current != nil {
+				route = routes[current.GetName()]
+			}
+			if route == nil || route.Method != r.Method {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			err := openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: mux.Vars(r),
+				Route:      route,
+				Options:    options,
+			})
+			if err != nil {
+				respondProblem(w, r, &requestError{
+					code:   CodeInvalidRequest,
+					detail: "Request doesn't match the OpenAPI document",
+					issues: requestIssues(err),
+					err:    err,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestIssues flattens the errors found validating a request.
+func requestIssues(err error) []*RequestIssue {
+	// Request errors unwrap to the schema errors they contain, so only errors
+	// collected across the request are flattened here.
+	if multi, ok := err.(openapi3.MultiError); ok {
+		var issues []*RequestIssue
+		for _, err := range multi {
+			issues = append(issues, requestIssues(err)...)
+		}
+		return issues
+	}
+
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return []*RequestIssue{{Message: err.Error()}}
+	}
+
+	issue := RequestIssue{Message: reqErr.Reason}
+	switch {
+	case reqErr.Parameter != nil:
+		issue.In = reqErr.Parameter.In
+		issue.Name = reqErr.Parameter.Name
+	case reqErr.RequestBody != nil:
+		issue.In = "body"
+	}
+
+	// Schema errors in the body are reported against the property at fault.
+	var schemaErrs []*openapi3.SchemaError
+	if multi, ok := reqErr.Err.(openapi3.MultiError); ok {
+		for _, err := range multi {
+			var schemaErr *openapi3.SchemaError
+			if errors.As(err, &schemaErr) {
+				schemaErrs = append(schemaErrs, schemaErr)
+			}
+		}
+	} else {
+		var schemaErr *openapi3.SchemaError
+		if errors.As(reqErr.Err, &schemaErr) {
+			schemaErrs = append(schemaErrs, schemaErr)
+		}
+	}
+
+	if len(schemaErrs) == 0 {
+		if issue.Message == "" && reqErr.Err != nil {
+			issue.Message = reqErr.Err.Error()
+		}
+		return []*RequestIssue{&issue}
+	}
+
+	var issues []*RequestIssue
+	for _, schemaErr := range schemaErrs {
+		issue := issue
+		issue.Message = schemaErr.Reason
+		if issue.In == "body" {
+			issue.Name = strings.Join(schemaErr.JSONPointer(), ".")
+		}
+		issues = append(issues, &issue)
+	}
+	return issues
+}
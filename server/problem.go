@@ -0,0 +1,198 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/httpapi"
+	"github.com/jace-ys/simple-api/logging"
+)
+
+// ErrorCode identifies the kind of problem in an error response. Codes are
+// stable, so clients can rely on them instead of matching messages.
+type ErrorCode string
+
+const (
+	CodeInvalidRequest         ErrorCode = "invalid_request"
+	CodeUnauthorized           ErrorCode = "unauthorized"
+	CodeMovieNotFound          ErrorCode = "movie_not_found"
+	CodeSagaNotFound           ErrorCode = "saga_not_found"
+	CodePhaseNotFound          ErrorCode = "phase_not_found"
+	CodeReviewNotFound         ErrorCode = "review_not_found"
+	CodeWatchlistEntryNotFound ErrorCode = "watchlist_entry_not_found"
+	CodeChangesExpired         ErrorCode = "changes_expired"
+	CodeDownstreamUnavailable  ErrorCode = "downstream_unavailable"
+	CodeInternal               ErrorCode = "internal_error"
+)
+
+type problemType struct {
+	status int
+	title  string
+}
+
+// problemTypes is the catalogue of problems responded with, keyed by code.
+var problemTypes = map[ErrorCode]problemType{
+	CodeInvalidRequest:         {http.StatusBadRequest, "Invalid request"},
+	CodeUnauthorized:           {http.StatusUnauthorized, "Unauthorized"},
+	CodeMovieNotFound:          {http.StatusNotFound, "Movie not found"},
+	CodeSagaNotFound:           {http.StatusNotFound, "Saga not found"},
+	CodePhaseNotFound:          {http.StatusNotFound, "Phase not found"},
+	CodeReviewNotFound:         {http.StatusNotFound, "Review not found"},
+	CodeWatchlistEntryNotFound: {http.StatusNotFound, "Movie not on watchlist"},
+	CodeChangesExpired:         {http.StatusGone, "Changes since this version are no longer available, reload the catalog"},
+	CodeDownstreamUnavailable:  {http.StatusServiceUnavailable, "Downstream service unavailable"},
+	CodeInternal:               {http.StatusInternalServerError, "Internal server error"},
+}
+
+// problemTypeURI returns the URI identifying the problem type of code.
+func problemTypeURI(code ErrorCode) string {
+	return "urn:simple-api:problem:" + string(code)
+}
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response body.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Code      ErrorCode `json:"code"`
+	RequestID string    `json:"request_id,omitempty"`
+	// Issues are reported when a request is invalid.
+	Issues []*RequestIssue `json:"issues,omitempty"`
+	// Suggestions are offered when a saga isn't found.
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// RequestIssue describes a parameter or body property that is invalid.
+type RequestIssue struct {
+	// In is where the problem is: path, query or body.
+	In string `json:"in"`
+	// Name is the parameter, or the path to the body property, at fault.
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+// requestError is returned by handlers for problems the domain has no error
+// for, such as invalid requests.
+type requestError struct {
+	code   ErrorCode
+	detail string
+	issues []*RequestIssue
+	// err is the cause of the problem, if any.
+	err error
+}
+
+func (e *requestError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("%s: %s: %s", e.code, e.detail, e.err)
+	}
+	return fmt.Sprintf("%s: %s", e.code, e.detail)
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// invalidRequest reports a request that has the given issues.
+func invalidRequest(detail string, issues ...*RequestIssue) error {
+	return &requestError{code: CodeInvalidRequest, detail: detail, issues: issues}
+}
+
+// invalidParam reports an invalid query parameter.
+func invalidParam(name, format string, args ...interface{}) error {
+	return invalid("query", name, fmt.Sprintf(format, args...))
+}
+
+// invalidPathParam reports an invalid path parameter.
+func invalidPathParam(name, format string, args ...interface{}) error {
+	return invalid("path", name, fmt.Sprintf(format, args...))
+}
+
+// invalidBody reports an invalid request body, or property of it if name is
+// set.
+func invalidBody(name, format string, args ...interface{}) error {
+	return invalid("body", name, fmt.Sprintf(format, args...))
+}
+
+func invalid(in, name, message string) error {
+	return invalidRequest(message, &RequestIssue{In: in, Name: name, Message: message})
+}
+
+// problemFor maps err to the problem responded with.
+func problemFor(err error) *Problem {
+	var reqErr *requestError
+	var sagaNotFound *domain.SagaNotFoundError
+	switch {
+	case errors.As(err, &reqErr):
+		p := newProblem(reqErr.code)
+		p.Detail = reqErr.detail
+		p.Issues = reqErr.issues
+		return p
+	case errors.As(err, &sagaNotFound):
+		p := newProblem(CodeSagaNotFound)
+		p.Suggestions = sagaNotFound.Suggestions
+		return p
+	case errors.Is(err, domain.ErrSagaNotFound):
+		return newProblem(CodeSagaNotFound)
+	case errors.Is(err, domain.ErrMovieNotFound):
+		return newProblem(CodeMovieNotFound)
+	case errors.Is(err, domain.ErrPhaseNotFound):
+		return newProblem(CodePhaseNotFound)
+	case errors.Is(err, domain.ErrReviewNotFound):
+		return newProblem(CodeReviewNotFound)
+	case errors.Is(err, domain.ErrWatchlistEntryNotFound):
+		return newProblem(CodeWatchlistEntryNotFound)
+	case errors.Is(err, domain.ErrChangesExpired):
+		return newProblem(CodeChangesExpired)
+	case errors.Is(err, domain.ErrInvalidMarathonPlan):
+		p := newProblem(CodeInvalidRequest)
+		p.Detail = "Invalid marathon plan"
+		return p
+	case errors.Is(err, httpapi.ErrDownstreamUnavailable):
+		return newProblem(CodeDownstreamUnavailable)
+	default:
+		return newProblem(CodeInternal)
+	}
+}
+
+func newProblem(code ErrorCode) *Problem {
+	t := problemTypes[code]
+	return &Problem{
+		Type:   problemTypeURI(code),
+		Title:  t.title,
+		Status: t.status,
+		Code:   code,
+	}
+}
+
+// handlerFunc is an HTTP handler that returns an error instead of responding
+// when a request fails, leaving the error response to respondProblem.
+type handlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (fn handlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := fn(w, r); err != nil {
+		respondProblem(w, r, err)
+	}
+}
+
+// respondProblem logs err and responds with the problem it maps to.
+func respondProblem(w http.ResponseWriter, r *http.Request, err error) {
+	p := problemFor(err)
+	p.Instance = r.URL.Path
+	p.RequestID = logging.RequestIDFromContext(r.Context())
+
+	level := slog.LevelWarn
+	if p.Status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	logging.FromContext(r.Context()).Log(r.Context(), level, "request error", slog.Any("error", err), slog.String("code", string(p.Code)))
+
+	respondJSONAs(w, problemContentType, p.Status, p)
+}
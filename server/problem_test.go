@@ -0,0 +1,85 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/httpapi"
+	"github.com/jace-ys/simple-api/logging"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestProblems(t *testing.T) {
+	tt := []struct {
+		Name           string
+		Endpoint       string
+		Err            error
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		{
+			Name:           "Responds with movie_not_found for a missing movie",
+			Endpoint:       "/movies/4",
+			Err:            fmt.Errorf("get movie: %w", domain.ErrMovieNotFound),
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   `{"type": "urn:simple-api:problem:movie_not_found", "title": "Movie not found", "status": 404, "instance": "/movies/4", "code": "movie_not_found", "request_id": "abc123"}`,
+		},
+		{
+			Name:           "Responds with downstream_unavailable when upstream is down",
+			Endpoint:       "/movies/4",
+			Err:            fmt.Errorf("%w: 502 Bad Gateway", httpapi.ErrDownstreamUnavailable),
+			ExpectedStatus: http.StatusServiceUnavailable,
+			ExpectedBody:   `{"type": "urn:simple-api:problem:downstream_unavailable", "title": "Downstream service unavailable", "status": 503, "instance": "/movies/4", "code": "downstream_unavailable", "request_id": "abc123"}`,
+		},
+		{
+			Name:           "Responds with internal_error for unknown errors",
+			Endpoint:       "/movies/4",
+			Err:            errors.New("internal server error"),
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedBody:   `{"type": "urn:simple-api:problem:internal_error", "title": "Internal server error", "status": 500, "instance": "/movies/4", "code": "internal_error", "request_id": "abc123"}`,
+		},
+		{
+			Name:           "Responds with invalid_request and the parameter at fault",
+			Endpoint:       "/movies/iron-man",
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody: `{"type": "urn:simple-api:problem:invalid_request", "title": "Invalid request", "status": 400, "detail": "Invalid movie ID", "instance": "/movies/iron-man", "code": "invalid_request", "request_id": "abc123", "issues": [
+				{"in": "path", "name": "id", "message": "Invalid movie ID"}
+			]}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMovieReturns(nil, tc.Err)
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", tc.Endpoint, nil)
+			assert.NoError(t, err)
+			req.Header.Set("X-Request-ID", "abc123")
+
+			rw := httptest.NewRecorder()
+			server.LogRequests(logging.New(io.Discard, 0), router).ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+			assert.Equal(t, "application/problem+json", rw.Header().Get("Content-Type"))
+
+			var body json.RawMessage
+			assert.NoError(t, json.NewDecoder(rw.Body).Decode(&body))
+			assert.JSONEq(t, tc.ExpectedBody, string(body))
+		})
+	}
+}
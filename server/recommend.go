@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/recommend"
+)
+
+const (
+	defaultRecommendationsLimit = 5
+	maxRecommendationsLimit     = 20
+)
+
+type RecommendationsHandler struct {
+	movies     domain.MoviesService
+	watchlists domain.WatchlistStore
+	engine     snapshotCache
+}
+
+func NewRecommendationsHandler(movies domain.MoviesService, watchlists domain.WatchlistStore) *RecommendationsHandler {
+	return &RecommendationsHandler{
+		movies:     movies,
+		watchlists: watchlists,
+	}
+}
+
+func (h *RecommendationsHandler) RegisterRoutes(r *mux.Router) {
+	r.Handle("/recommendations", handlerFunc(h.GetRecommendations)).Methods(http.MethodGet).Name("getRecommendations")
+}
+
+// GetRecommendations suggests movies to watch next based on either a single
+// movie (movie_id), a list of watched movies (watched) or the movies a user
+// has marked as watched on their watchlist (user).
+func (h *RecommendationsHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+
+	limit := defaultRecommendationsLimit
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxRecommendationsLimit {
+			return invalidParam("limit", "Invalid limit, must be between 1 and %d", maxRecommendationsLimit)
+		}
+		limit = n
+	}
+
+	var given int
+	for _, param := range []string{"movie_id", "watched", "user"} {
+		if q.Get(param) != "" {
+			given++
+		}
+	}
+	if given != 1 {
+		return invalidRequest("Exactly one of movie_id, watched or user is required")
+	}
+
+	seeds, err := h.seeds(r)
+	if err != nil {
+		return err
+	}
+
+	engine, err := h.engine.get(r.Context(), h.movies, func(movies domain.Movies) interface{} {
+		return recommend.NewEngine(movies)
+	})
+	if err != nil {
+		return err
+	}
+
+	recs := engine.(*recommend.Engine).Recommend(seeds, limit)
+
+	setResultCount(r, len(recs))
+	respondJSON(w, http.StatusOK, recs)
+	return nil
+}
+
+// seeds returns the IDs of the movies to base recommendations on.
+func (h *RecommendationsHandler) seeds(r *http.Request) ([]int, error) {
+	q := r.URL.Query()
+
+	switch {
+	case q.Get("movie_id") != "":
+		movieID, err := strconv.Atoi(q.Get("movie_id"))
+		if err != nil {
+			return nil, invalidParam("movie_id", "Invalid movie ID")
+		}
+		if _, err := h.movies.GetMovie(r.Context(), movieID); err != nil {
+			return nil, err
+		}
+		return []int{movieID}, nil
+
+	case q.Get("watched") != "":
+		var seeds []int
+		for _, v := range strings.Split(q.Get("watched"), ",") {
+			movieID, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return nil, invalidParam("watched", "Invalid movie ID %q in watched", v)
+			}
+			seeds = append(seeds, movieID)
+		}
+		return seeds, nil
+
+	default:
+		watchlist, err := h.watchlists.GetWatchlist(r.Context(), q.Get("user"))
+		if err != nil {
+			return nil, err
+		}
+
+		var seeds []int
+		for _, entry := range watchlist {
+			if entry.WatchedAt != nil {
+				seeds = append(seeds, entry.MovieID)
+			}
+		}
+		return seeds, nil
+	}
+}
@@ -0,0 +1,166 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/recommend"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestGetRecommendations(t *testing.T) {
+	watchedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	movies := domain.Movies{
+		{ID: 1, Title: "Hello World 1", Phase: 1, Saga: "Epilogue", Chronology: 1},
+		{ID: 2, Title: "Hello World 2", Phase: 1, Saga: "Epilogue", Chronology: 2},
+		{ID: 3, Title: "Hello World 3", Phase: 2, Saga: "Epilogue", Chronology: 3},
+	}
+
+	tt := []struct {
+		Name           string
+		QueryParams    string
+		SetupFakes     func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore)
+		ExpectedStatus int
+		ExpectedIDs    []int
+	}{
+		{
+			Name:        "Returns status 200 with recommendations for a movie",
+			QueryParams: "?movie_id=1",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(&domain.Movie{ID: 1}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedIDs:    []int{2, 3},
+		},
+		{
+			Name:           "Returns status 200 with recommendations for watched movies",
+			QueryParams:    "?watched=1,2&limit=1",
+			ExpectedStatus: http.StatusOK,
+			ExpectedIDs:    []int{3},
+		},
+		{
+			Name:        "Returns status 200 with recommendations for a user",
+			QueryParams: "?user=alice",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				watchlists.GetWatchlistReturns(domain.Watchlist{
+					{MovieID: 2, WatchedAt: &watchedAt},
+					{MovieID: 3},
+				}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedIDs:    []int{3, 1},
+		},
+		{
+			Name:        "Returns status 404 when movie is not found",
+			QueryParams: "?movie_id=99",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(nil, domain.ErrMovieNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:           "Returns status 400 without a seed",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 with more than one seed",
+			QueryParams:    "?movie_id=1&user=alice",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when watched is invalid",
+			QueryParams:    "?watched=1,two",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when limit is invalid",
+			QueryParams:    "?watched=1&limit=100",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:        "Returns status 500 when service request fails",
+			QueryParams: "?watched=1",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMoviesReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMoviesReturns(movies, nil)
+			watchlists := new(domainfakes.FakeWatchlistStore)
+			if tc.SetupFakes != nil {
+				tc.SetupFakes(service, watchlists)
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewRecommendationsHandler(service, watchlists)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", "/recommendations"+tc.QueryParams, nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			if tc.ExpectedIDs != nil {
+				var res []*recommend.Recommendation
+				json.NewDecoder(rw.Body).Decode(&res)
+
+				var ids []int
+				for _, rec := range res {
+					ids = append(ids, rec.Movie.ID)
+					assert.NotEmpty(t, rec.Reasons)
+				}
+				assert.Equal(t, tc.ExpectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestGetRecommendationsReusesEngine(t *testing.T) {
+	service := &fakeSnapshotMoviesService{
+		FakeMoviesService: new(domainfakes.FakeMoviesService),
+		loadedAt:          time.Now(),
+	}
+	service.GetMoviesReturns(domain.Movies{
+		{ID: 1, Title: "Hello World 1", Phase: 1, Saga: "Epilogue", Chronology: 1},
+		{ID: 2, Title: "Hello World 2", Phase: 1, Saga: "Epilogue", Chronology: 2},
+	}, nil)
+
+	router := mux.NewRouter()
+	handler := server.NewRecommendationsHandler(service, new(domainfakes.FakeWatchlistStore))
+	handler.RegisterRoutes(router)
+
+	recommend := func() {
+		req, err := http.NewRequest("GET", "/recommendations?watched=1", nil)
+		assert.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	}
+
+	recommend()
+	recommend()
+	assert.Equal(t, 1, service.GetMoviesCallCount())
+
+	service.loadedAt = service.loadedAt.Add(time.Minute)
+	recommend()
+	assert.Equal(t, 2, service.GetMoviesCallCount())
+}
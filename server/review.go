@@ -0,0 +1,187 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type ReviewsHandler struct {
+	movies  domain.MoviesService
+	reviews domain.ReviewStore
+	now     func() time.Time
+}
+
+func NewReviewsHandler(movies domain.MoviesService, reviews domain.ReviewStore) *ReviewsHandler {
+	return &ReviewsHandler{
+		movies:  movies,
+		reviews: reviews,
+		now:     time.Now,
+	}
+}
+
+func (h *ReviewsHandler) RegisterRoutes(r *mux.Router) {
+	r.Handle("/movies/{id}/reviews", handlerFunc(h.GetReviews)).Methods(http.MethodGet).Name("listReviews")
+	r.Handle("/movies/{id}/reviews/{user}", handlerFunc(h.GetReview)).Methods(http.MethodGet).Name("getReview")
+	r.Handle("/movies/{id}/reviews/{user}", handlerFunc(h.PutReview)).Methods(http.MethodPut).Name("putReview")
+	r.Handle("/movies/{id}/reviews/{user}", handlerFunc(h.DeleteReview)).Methods(http.MethodDelete).Name("deleteReview")
+	r.Handle("/movies/{id}/reviews/{user}/flags", handlerFunc(h.FlagReview)).Methods(http.MethodPost).Name("flagReview")
+}
+
+// RegisterAdminRoutes registers the moderation routes, which must only be
+// served to admins, e.g. behind RequireAdminToken.
+func (h *ReviewsHandler) RegisterAdminRoutes(r *mux.Router) {
+	r.Handle("/movies/{id}/reviews/{user}/moderation", handlerFunc(h.ModerateReview)).Methods(http.MethodPut).Name("moderateReview")
+}
+
+func (h *ReviewsHandler) GetReviews(w http.ResponseWriter, r *http.Request) error {
+	movieID, err := knownMovieFromPath(r, h.movies)
+	if err != nil {
+		return err
+	}
+
+	reviews, err := h.reviews.GetReviews(r.Context(), movieID)
+	if err != nil {
+		return err
+	}
+
+	visible := []*domain.Review{}
+	for _, review := range reviews {
+		if review.Visible() {
+			visible = append(visible, review)
+		}
+	}
+
+	setResultCount(r, len(visible))
+	respondJSON(w, http.StatusOK, visible)
+	return nil
+}
+
+func (h *ReviewsHandler) GetReview(w http.ResponseWriter, r *http.Request) error {
+	movieID, err := knownMovieFromPath(r, h.movies)
+	if err != nil {
+		return err
+	}
+	userID := mux.Vars(r)["user"]
+
+	review, err := h.reviews.GetReview(r.Context(), movieID, userID)
+	if err != nil {
+		return err
+	}
+	if !review.Visible() {
+		return domain.ErrReviewNotFound
+	}
+
+	respondJSON(w, http.StatusOK, review)
+	return nil
+}
+
+type PutReviewRequest struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+func (h *ReviewsHandler) PutReview(w http.ResponseWriter, r *http.Request) error {
+	body := &PutReviewRequest{}
+	if err := decodeJSON(r, body); err != nil {
+		return err
+	}
+
+	body.Text = strings.TrimSpace(body.Text)
+	switch {
+	case body.Rating < domain.MinRating || body.Rating > domain.MaxRating:
+		return invalidBody("rating", "Invalid rating, must be between %d and %d", domain.MinRating, domain.MaxRating)
+	case utf8.RuneCountInString(body.Text) > domain.MaxReviewTextLength:
+		return invalidBody("text", "Invalid text, must be at most %d characters", domain.MaxReviewTextLength)
+	}
+
+	movieID, err := knownMovieFromPath(r, h.movies)
+	if err != nil {
+		return err
+	}
+
+	review, created, err := h.reviews.PutReview(r.Context(), &domain.Review{
+		MovieID:   movieID,
+		UserID:    mux.Vars(r)["user"],
+		Rating:    body.Rating,
+		Text:      body.Text,
+		UpdatedAt: h.now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	respondJSON(w, status, review)
+	return nil
+}
+
+func (h *ReviewsHandler) DeleteReview(w http.ResponseWriter, r *http.Request) error {
+	movieID, err := movieIDFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	if err := h.reviews.DeleteReview(r.Context(), movieID, mux.Vars(r)["user"]); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (h *ReviewsHandler) FlagReview(w http.ResponseWriter, r *http.Request) error {
+	movieID, err := movieIDFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	review, err := h.reviews.FlagReview(r.Context(), movieID, mux.Vars(r)["user"])
+	if err != nil {
+		return err
+	}
+	if !review.Visible() {
+		return domain.ErrReviewNotFound
+	}
+
+	respondJSON(w, http.StatusOK, review)
+	return nil
+}
+
+type ModerateReviewRequest struct {
+	Moderation domain.ModerationStatus `json:"moderation"`
+}
+
+func (h *ReviewsHandler) ModerateReview(w http.ResponseWriter, r *http.Request) error {
+	movieID, err := movieIDFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	body := &ModerateReviewRequest{}
+	if err := decodeJSON(r, body); err != nil {
+		return err
+	}
+
+	switch body.Moderation {
+	case domain.ModerationPublished, domain.ModerationFlagged, domain.ModerationHidden:
+	default:
+		return invalidBody("moderation", "Invalid moderation, must be published, flagged or hidden")
+	}
+
+	review, err := h.reviews.SetModeration(r.Context(), movieID, mux.Vars(r)["user"], body.Moderation)
+	if err != nil {
+		return err
+	}
+
+	respondJSON(w, http.StatusOK, review)
+	return nil
+}
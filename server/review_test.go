@@ -0,0 +1,363 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestReviews(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	movie := &domain.Movie{ID: 1, Title: "Hello World 1"}
+	review := &domain.Review{
+		MovieID:    1,
+		UserID:     "alice",
+		Rating:     8,
+		Text:       "Great",
+		Moderation: domain.ModerationPublished,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+	hidden := &domain.Review{
+		MovieID:    1,
+		UserID:     "bob",
+		Rating:     1,
+		Moderation: domain.ModerationHidden,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+
+	tt := []struct {
+		Name           string
+		Method         string
+		Endpoint       string
+		Token          string
+		Body           string
+		SetupFakes     func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore)
+		ExpectedStatus int
+		ExpectedBody   interface{}
+		Assert         func(t *testing.T, reviews *domainfakes.FakeReviewStore)
+	}{
+		{
+			Name:     "Returns status 200 with visible reviews",
+			Method:   http.MethodGet,
+			Endpoint: "/movies/1/reviews",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				movies.GetMovieReturns(movie, nil)
+				reviews.GetReviewsReturns([]*domain.Review{review, hidden}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   []*domain.Review{review},
+		},
+		{
+			Name:     "Returns status 404 when movie is not found",
+			Method:   http.MethodGet,
+			Endpoint: "/movies/99/reviews",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				movies.GetMovieReturns(nil, domain.ErrMovieNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 200 with a single review",
+			Method:   http.MethodGet,
+			Endpoint: "/movies/1/reviews/alice",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				movies.GetMovieReturns(movie, nil)
+				reviews.GetReviewReturns(review, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   review,
+		},
+		{
+			Name:     "Returns status 404 when review is hidden",
+			Method:   http.MethodGet,
+			Endpoint: "/movies/1/reviews/bob",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				movies.GetMovieReturns(movie, nil)
+				reviews.GetReviewReturns(hidden, nil)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 201 when creating a review",
+			Method:   http.MethodPut,
+			Endpoint: "/movies/1/reviews/alice",
+			Body:     `{"rating": 8, "text": "  Great  "}`,
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				movies.GetMovieReturns(movie, nil)
+				reviews.PutReviewReturns(review, true, nil)
+			},
+			ExpectedStatus: http.StatusCreated,
+			ExpectedBody:   review,
+			Assert: func(t *testing.T, reviews *domainfakes.FakeReviewStore) {
+				_, put := reviews.PutReviewArgsForCall(0)
+				assert.Equal(t, 1, put.MovieID)
+				assert.Equal(t, "alice", put.UserID)
+				assert.Equal(t, 8, put.Rating)
+				assert.Equal(t, "Great", put.Text)
+			},
+		},
+		{
+			Name:     "Returns status 200 when replacing a review",
+			Method:   http.MethodPut,
+			Endpoint: "/movies/1/reviews/alice",
+			Body:     `{"rating": 8, "text": "Great"}`,
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				movies.GetMovieReturns(movie, nil)
+				reviews.PutReviewReturns(review, false, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   review,
+		},
+		{
+			Name:           "Returns status 400 when rating is out of range",
+			Method:         http.MethodPut,
+			Endpoint:       "/movies/1/reviews/alice",
+			Body:           `{"rating": 11}`,
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 400 when text is too long",
+			Method:         http.MethodPut,
+			Endpoint:       "/movies/1/reviews/alice",
+			Body:           `{"rating": 5, "text": "` + strings.Repeat("a", domain.MaxReviewTextLength+1) + `"}`,
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "Returns status 404 when reviewing an unknown movie",
+			Method:   http.MethodPut,
+			Endpoint: "/movies/99/reviews/alice",
+			Body:     `{"rating": 5}`,
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				movies.GetMovieReturns(nil, domain.ErrMovieNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+			Assert: func(t *testing.T, reviews *domainfakes.FakeReviewStore) {
+				assert.Equal(t, 0, reviews.PutReviewCallCount())
+			},
+		},
+		{
+			Name:           "Returns status 204 when deleting a review",
+			Method:         http.MethodDelete,
+			Endpoint:       "/movies/1/reviews/alice",
+			ExpectedStatus: http.StatusNoContent,
+		},
+		{
+			Name:     "Returns status 404 when deleting a missing review",
+			Method:   http.MethodDelete,
+			Endpoint: "/movies/1/reviews/alice",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				reviews.DeleteReviewReturns(domain.ErrReviewNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 200 when flagging a review",
+			Method:   http.MethodPost,
+			Endpoint: "/movies/1/reviews/alice/flags",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				reviews.FlagReviewReturns(review, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   review,
+		},
+		{
+			Name:     "Returns status 404 when flagging a hidden review",
+			Method:   http.MethodPost,
+			Endpoint: "/movies/1/reviews/bob/flags",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				reviews.FlagReviewReturns(hidden, nil)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 200 when moderating a review",
+			Method:   http.MethodPut,
+			Endpoint: "/admin/movies/1/reviews/bob/moderation",
+			Token:    "s3cret",
+			Body:     `{"moderation": "hidden"}`,
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				reviews.SetModerationReturns(hidden, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   hidden,
+			Assert: func(t *testing.T, reviews *domainfakes.FakeReviewStore) {
+				_, movieID, userID, status := reviews.SetModerationArgsForCall(0)
+				assert.Equal(t, 1, movieID)
+				assert.Equal(t, "bob", userID)
+				assert.Equal(t, domain.ModerationHidden, status)
+			},
+		},
+		{
+			Name:           "Returns status 400 when moderation is invalid",
+			Method:         http.MethodPut,
+			Endpoint:       "/admin/movies/1/reviews/bob/moderation",
+			Token:          "s3cret",
+			Body:           `{"moderation": "deleted"}`,
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 401 when moderating without the admin token",
+			Method:         http.MethodPut,
+			Endpoint:       "/admin/movies/1/reviews/bob/moderation",
+			Body:           `{"moderation": "hidden"}`,
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		{
+			Name:           "Returns status 401 when moderating with the wrong admin token",
+			Method:         http.MethodPut,
+			Endpoint:       "/admin/movies/1/reviews/bob/moderation",
+			Token:          "guess",
+			Body:           `{"moderation": "hidden"}`,
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		{
+			Name:           "Returns status 404 when moderating outside the admin routes",
+			Method:         http.MethodPut,
+			Endpoint:       "/movies/1/reviews/bob/moderation",
+			Body:           `{"moderation": "hidden"}`,
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 500 when store request fails",
+			Method:   http.MethodGet,
+			Endpoint: "/movies/1/reviews",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, reviews *domainfakes.FakeReviewStore) {
+				movies.GetMovieReturns(movie, nil)
+				reviews.GetReviewsReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			movies := new(domainfakes.FakeMoviesService)
+			reviews := new(domainfakes.FakeReviewStore)
+			if tc.SetupFakes != nil {
+				tc.SetupFakes(movies, reviews)
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewReviewsHandler(movies, reviews)
+			handler.RegisterRoutes(router)
+
+			admin := router.PathPrefix("/admin").Subrouter()
+			admin.Use(server.RequireAdminToken("s3cret"))
+			handler.RegisterAdminRoutes(admin)
+
+			req, err := http.NewRequest(tc.Method, tc.Endpoint, strings.NewReader(tc.Body))
+			assert.NoError(t, err)
+			if tc.Token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.Token)
+			}
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			switch expected := tc.ExpectedBody.(type) {
+			case []*domain.Review:
+				var res []*domain.Review
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			case *domain.Review:
+				var res *domain.Review
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			}
+
+			if tc.Assert != nil {
+				tc.Assert(t, reviews)
+			}
+		})
+	}
+}
+
+func TestMoviesRatings(t *testing.T) {
+	movie := &domain.Movie{ID: 1, Title: "Hello World 1"}
+
+	tt := []struct {
+		Name           string
+		Endpoint       string
+		SetupFake      func(fake *domainfakes.FakeRatingsService)
+		ExpectedRating float64
+		ExpectedCount  int
+	}{
+		{
+			Name:     "Includes ratings in movies",
+			Endpoint: "/movies",
+			SetupFake: func(fake *domainfakes.FakeRatingsService) {
+				fake.GetRatingsReturns(map[int]*domain.Rating{1: {Average: 7.5, Count: 2}}, nil)
+			},
+			ExpectedRating: 7.5,
+			ExpectedCount:  2,
+		},
+		{
+			Name:     "Includes ratings in movie",
+			Endpoint: "/movies/1",
+			SetupFake: func(fake *domainfakes.FakeRatingsService) {
+				fake.GetRatingsReturns(map[int]*domain.Rating{1: {Average: 7.5, Count: 2}}, nil)
+			},
+			ExpectedRating: 7.5,
+			ExpectedCount:  2,
+		},
+		{
+			Name:     "Serves unrated movies when ratings fail",
+			Endpoint: "/movies/1",
+			SetupFake: func(fake *domainfakes.FakeRatingsService) {
+				fake.GetRatingsReturns(nil, errors.New("internal server error"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			service := new(domainfakes.FakeMoviesService)
+			service.GetMoviesReturns(domain.Movies{movie}, nil)
+			service.GetMovieReturns(movie, nil)
+
+			ratings := new(domainfakes.FakeRatingsService)
+			tc.SetupFake(ratings)
+
+			router := mux.NewRouter()
+			handler := server.NewMCUHandler(service).WithRatings(ratings)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest("GET", tc.Endpoint, nil)
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, http.StatusOK, rw.Code)
+
+			var res *domain.Movie
+			if tc.Endpoint == "/movies" {
+				var movies domain.Movies
+				json.NewDecoder(rw.Body).Decode(&movies)
+				res = movies[0]
+			} else {
+				json.NewDecoder(rw.Body).Decode(&res)
+			}
+
+			assert.Equal(t, tc.ExpectedRating, res.Rating)
+			assert.Equal(t, tc.ExpectedCount, res.ReviewCount)
+			assert.Zero(t, movie.Rating, "the service's movies aren't modified")
+		})
+	}
+}
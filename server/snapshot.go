@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+// snapshotter is implemented by MoviesServices that serve a snapshot of movies,
+// such as catalog.Catalog, which refreshes it periodically, and mcu.Snapshot.
+type snapshotter interface {
+	LoadedAt() time.Time
+}
+
+// snapshotCache keeps a value built from the movies served by a MoviesService,
+// such as a search index, for as long as the same snapshot is served. Values
+// are rebuilt on every call for services that don't serve snapshots.
+type snapshotCache struct {
+	mu        sync.Mutex
+	value     interface{}
+	builtFrom time.Time
+}
+
+// get returns the value built from the current movies, building it with build
+// unless it's cached.
+func (c *snapshotCache) get(ctx context.Context, movies domain.MoviesService, build func(domain.Movies) interface{}) (interface{}, error) {
+	var loadedAt time.Time
+	if s, ok := movies.(snapshotter); ok {
+		loadedAt = s.LoadedAt()
+	}
+
+	c.mu.Lock()
+	value, builtFrom := c.value, c.builtFrom
+	c.mu.Unlock()
+
+	if value != nil && !loadedAt.IsZero() && loadedAt.Equal(builtFrom) {
+		return value, nil
+	}
+
+	all, err := movies.GetMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	value = build(all)
+	if !loadedAt.IsZero() {
+		c.mu.Lock()
+		if !c.builtFrom.After(loadedAt) {
+			c.value, c.builtFrom = value, loadedAt
+		}
+		c.mu.Unlock()
+	}
+
+	return value, nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jace-ys/simple-api/logging"
+)
+
+const tracerName = "github.com/jace-ys/simple-api/server"
+
+var tracer = otel.Tracer(tracerName)
+
+// TraceRoutes is a mux middleware that continues any W3C trace context sent by
+// the caller and wraps each request in a server span named after its route.
+// It uses the global tracer provider and propagator.
+func TraceRoutes(next http.Handler) http.Handler {
+	return traceRoutes(next, tracer, otel.GetTextMapPropagator())
+}
+
+// TraceRoutesWith is like TraceRoutes, but traces with tp and propagator
+// instead of the global ones.
+func TraceRoutesWith(tp trace.TracerProvider, propagator propagation.TextMapPropagator) mux.MiddlewareFunc {
+	tracer := tp.Tracer(tracerName)
+	return func(next http.Handler) http.Handler {
+		return traceRoutes(next, tracer, propagator)
+	}
+}
+
+func traceRoutes(next http.Handler, tracer trace.Tracer, propagator propagation.TextMapPropagator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		logger := logging.FromContext(ctx).With(slog.String("trace_id", span.SpanContext().TraceID().String()))
+		ctx = logging.WithLogger(ctx, logger)
+
+		m := httpsnoop.CaptureMetrics(next, w, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(m.Code))
+		if m.Code >= 500 {
+			span.SetStatus(codes.Error, http.StatusText(m.Code))
+		}
+	})
+}
+
+// setResultCount records the number of results a handler returned on the
+// request's span.
+func setResultCount(r *http.Request, n int) {
+	trace.SpanFromContext(r.Context()).SetAttributes(attribute.Int("result.count", n))
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+type WatchlistHandler struct {
+	movies     domain.MoviesService
+	watchlists domain.WatchlistStore
+	now        func() time.Time
+}
+
+func NewWatchlistHandler(movies domain.MoviesService, watchlists domain.WatchlistStore) *WatchlistHandler {
+	return &WatchlistHandler{
+		movies:     movies,
+		watchlists: watchlists,
+		now:        time.Now,
+	}
+}
+
+func (h *WatchlistHandler) RegisterRoutes(r *mux.Router) {
+	r.Handle("/users/{user}/watchlist", handlerFunc(h.GetWatchlist)).Methods(http.MethodGet).Name("getWatchlist")
+	r.Handle("/users/{user}/watchlist/progress", handlerFunc(h.GetProgress)).Methods(http.MethodGet).Name("getWatchlistProgress")
+	r.Handle("/users/{user}/watchlist/{id}", handlerFunc(h.AddToWatchlist)).Methods(http.MethodPut).Name("addToWatchlist")
+	r.Handle("/users/{user}/watchlist/{id}", handlerFunc(h.RemoveFromWatchlist)).Methods(http.MethodDelete).Name("removeFromWatchlist")
+	r.Handle("/users/{user}/watchlist/{id}/watched", handlerFunc(h.MarkWatched)).Methods(http.MethodPut).Name("markWatched")
+	r.Handle("/users/{user}/watchlist/{id}/watched", handlerFunc(h.MarkUnwatched)).Methods(http.MethodDelete).Name("markUnwatched")
+}
+
+func (h *WatchlistHandler) GetWatchlist(w http.ResponseWriter, r *http.Request) error {
+	watchlist, err := h.watchlists.GetWatchlist(r.Context(), mux.Vars(r)["user"])
+	if err != nil {
+		return err
+	}
+
+	setResultCount(r, len(watchlist))
+	respondJSON(w, http.StatusOK, watchlist)
+	return nil
+}
+
+func (h *WatchlistHandler) GetProgress(w http.ResponseWriter, r *http.Request) error {
+	watchlist, err := h.watchlists.GetWatchlist(r.Context(), mux.Vars(r)["user"])
+	if err != nil {
+		return err
+	}
+
+	movies, err := h.movies.GetMovies(r.Context())
+	if err != nil {
+		return err
+	}
+
+	respondJSON(w, http.StatusOK, movies.Progress(watchlist))
+	return nil
+}
+
+func (h *WatchlistHandler) AddToWatchlist(w http.ResponseWriter, r *http.Request) error {
+	movieID, err := knownMovieFromPath(r, h.movies)
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.watchlists.AddToWatchlist(r.Context(), mux.Vars(r)["user"], movieID, h.now())
+	if err != nil {
+		return err
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+	return nil
+}
+
+func (h *WatchlistHandler) RemoveFromWatchlist(w http.ResponseWriter, r *http.Request) error {
+	movieID, err := movieIDFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	if err := h.watchlists.RemoveFromWatchlist(r.Context(), mux.Vars(r)["user"], movieID); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+type MarkWatchedRequest struct {
+	WatchedAt *time.Time `json:"watched_at"`
+}
+
+func (h *WatchlistHandler) MarkWatched(w http.ResponseWriter, r *http.Request) error {
+	body := &MarkWatchedRequest{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil && !errors.Is(err, io.EOF) {
+		return invalidBody("watched_at", "Invalid request body, watched_at must be an RFC 3339 timestamp")
+	}
+
+	watchedAt := h.now()
+	if body.WatchedAt != nil {
+		watchedAt = *body.WatchedAt
+	}
+
+	return h.setWatched(w, r, &watchedAt)
+}
+
+func (h *WatchlistHandler) MarkUnwatched(w http.ResponseWriter, r *http.Request) error {
+	return h.setWatched(w, r, nil)
+}
+
+func (h *WatchlistHandler) setWatched(w http.ResponseWriter, r *http.Request, watchedAt *time.Time) error {
+	movieID, err := knownMovieFromPath(r, h.movies)
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.watchlists.SetWatched(r.Context(), mux.Vars(r)["user"], movieID, watchedAt)
+	if err != nil {
+		return err
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+	return nil
+}
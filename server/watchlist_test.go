@@ -0,0 +1,242 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/domain/domainfakes"
+	"github.com/jace-ys/simple-api/server"
+)
+
+func TestWatchlist(t *testing.T) {
+	addedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	watchedAt := time.Date(2024, 1, 2, 20, 0, 0, 0, time.UTC)
+
+	movie := &domain.Movie{ID: 1, Title: "Hello World 1", DurationMinutes: 120, Phase: 1, Saga: "Epilogue"}
+	entry := &domain.WatchlistEntry{MovieID: 1, AddedAt: addedAt}
+	watchedEntry := &domain.WatchlistEntry{MovieID: 1, AddedAt: addedAt, WatchedAt: &watchedAt}
+
+	tt := []struct {
+		Name           string
+		Method         string
+		Endpoint       string
+		Body           string
+		SetupFakes     func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore)
+		ExpectedStatus int
+		ExpectedBody   interface{}
+		Assert         func(t *testing.T, watchlists *domainfakes.FakeWatchlistStore)
+	}{
+		{
+			Name:     "Returns status 200 with the watchlist",
+			Method:   http.MethodGet,
+			Endpoint: "/users/alice/watchlist",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				watchlists.GetWatchlistReturns(domain.Watchlist{watchedEntry}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   domain.Watchlist{watchedEntry},
+			Assert: func(t *testing.T, watchlists *domainfakes.FakeWatchlistStore) {
+				_, userID := watchlists.GetWatchlistArgsForCall(0)
+				assert.Equal(t, "alice", userID)
+			},
+		},
+		{
+			Name:     "Returns status 200 when adding a movie",
+			Method:   http.MethodPut,
+			Endpoint: "/users/alice/watchlist/1",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(movie, nil)
+				watchlists.AddToWatchlistReturns(entry, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   entry,
+			Assert: func(t *testing.T, watchlists *domainfakes.FakeWatchlistStore) {
+				_, userID, movieID, _ := watchlists.AddToWatchlistArgsForCall(0)
+				assert.Equal(t, "alice", userID)
+				assert.Equal(t, 1, movieID)
+			},
+		},
+		{
+			Name:     "Returns status 404 when adding an unknown movie",
+			Method:   http.MethodPut,
+			Endpoint: "/users/alice/watchlist/99",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(nil, domain.ErrMovieNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+			Assert: func(t *testing.T, watchlists *domainfakes.FakeWatchlistStore) {
+				assert.Equal(t, 0, watchlists.AddToWatchlistCallCount())
+			},
+		},
+		{
+			Name:           "Returns status 400 when movie ID is invalid",
+			Method:         http.MethodPut,
+			Endpoint:       "/users/alice/watchlist/abc",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "Returns status 204 when removing a movie",
+			Method:         http.MethodDelete,
+			Endpoint:       "/users/alice/watchlist/1",
+			ExpectedStatus: http.StatusNoContent,
+		},
+		{
+			Name:     "Returns status 404 when removing a movie not on the watchlist",
+			Method:   http.MethodDelete,
+			Endpoint: "/users/alice/watchlist/1",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				watchlists.RemoveFromWatchlistReturns(domain.ErrWatchlistEntryNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 200 when marking a movie watched at a given time",
+			Method:   http.MethodPut,
+			Endpoint: "/users/alice/watchlist/1/watched",
+			Body:     `{"watched_at": "2024-01-02T20:00:00Z"}`,
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(movie, nil)
+				watchlists.SetWatchedReturns(watchedEntry, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   watchedEntry,
+			Assert: func(t *testing.T, watchlists *domainfakes.FakeWatchlistStore) {
+				_, _, _, at := watchlists.SetWatchedArgsForCall(0)
+				assert.Equal(t, &watchedAt, at)
+			},
+		},
+		{
+			Name:     "Returns status 200 when marking a movie watched now",
+			Method:   http.MethodPut,
+			Endpoint: "/users/alice/watchlist/1/watched",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(movie, nil)
+				watchlists.SetWatchedReturns(watchedEntry, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			Assert: func(t *testing.T, watchlists *domainfakes.FakeWatchlistStore) {
+				_, _, _, at := watchlists.SetWatchedArgsForCall(0)
+				assert.WithinDuration(t, time.Now(), *at, time.Minute)
+			},
+		},
+		{
+			Name:     "Returns status 200 when marking a movie unwatched",
+			Method:   http.MethodDelete,
+			Endpoint: "/users/alice/watchlist/1/watched",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(movie, nil)
+				watchlists.SetWatchedReturns(entry, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   entry,
+			Assert: func(t *testing.T, watchlists *domainfakes.FakeWatchlistStore) {
+				_, _, _, at := watchlists.SetWatchedArgsForCall(0)
+				assert.Nil(t, at)
+			},
+		},
+		{
+			Name:     "Returns status 400 when watched_at is invalid",
+			Method:   http.MethodPut,
+			Endpoint: "/users/alice/watchlist/1/watched",
+			Body:     `{"watched_at": "yesterday"}`,
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(movie, nil)
+			},
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "Returns status 404 when marking a movie not on the watchlist",
+			Method:   http.MethodPut,
+			Endpoint: "/users/alice/watchlist/1/watched",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMovieReturns(movie, nil)
+				watchlists.SetWatchedReturns(nil, domain.ErrWatchlistEntryNotFound)
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "Returns status 200 with progress per saga and phase",
+			Method:   http.MethodGet,
+			Endpoint: "/users/alice/watchlist/progress",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				movies.GetMoviesReturns(domain.Movies{
+					movie,
+					{ID: 2, Title: "Hello World 2", DurationMinutes: 100, Phase: 1, Saga: "Epilogue"},
+					{ID: 3, Title: "Hello World 3", DurationMinutes: 90, Phase: 2, Saga: "Epilogue"},
+				}, nil)
+				watchlists.GetWatchlistReturns(domain.Watchlist{watchedEntry}, nil)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: []*domain.SagaProgress{
+				{
+					Saga:          "Epilogue",
+					Slug:          "epilogue",
+					WatchProgress: domain.WatchProgress{Watched: 1, Total: 3, Percent: 33.33, WatchedMinutes: 120, RemainingMinutes: 190},
+					Phases: []*domain.PhaseProgress{
+						{Phase: 1, WatchProgress: domain.WatchProgress{Watched: 1, Total: 2, Percent: 50, WatchedMinutes: 120, RemainingMinutes: 100}},
+						{Phase: 2, WatchProgress: domain.WatchProgress{Watched: 0, Total: 1, Percent: 0, WatchedMinutes: 0, RemainingMinutes: 90}},
+					},
+				},
+			},
+		},
+		{
+			Name:     "Returns status 500 when store request fails",
+			Method:   http.MethodGet,
+			Endpoint: "/users/alice/watchlist",
+			SetupFakes: func(movies *domainfakes.FakeMoviesService, watchlists *domainfakes.FakeWatchlistStore) {
+				watchlists.GetWatchlistReturns(nil, errors.New("internal server error"))
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			movies := new(domainfakes.FakeMoviesService)
+			watchlists := new(domainfakes.FakeWatchlistStore)
+			if tc.SetupFakes != nil {
+				tc.SetupFakes(movies, watchlists)
+			}
+
+			router := mux.NewRouter()
+			handler := server.NewWatchlistHandler(movies, watchlists)
+			handler.RegisterRoutes(router)
+
+			req, err := http.NewRequest(tc.Method, tc.Endpoint, strings.NewReader(tc.Body))
+			assert.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rw.Code)
+
+			switch expected := tc.ExpectedBody.(type) {
+			case domain.Watchlist:
+				var res domain.Watchlist
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			case *domain.WatchlistEntry:
+				var res *domain.WatchlistEntry
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			case []*domain.SagaProgress:
+				var res []*domain.SagaProgress
+				json.NewDecoder(rw.Body).Decode(&res)
+				assert.Equal(t, expected, res)
+			}
+
+			if tc.Assert != nil {
+				tc.Assert(t, watchlists)
+			}
+		})
+	}
+}
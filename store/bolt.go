@@ -0,0 +1,371 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+var watchlistsBucket = []byte("watchlists")
+
+// BoltWatchlists is a WatchlistStore backed by an embedded bbolt database
+// file, with each user's watchlist stored as JSON under their ID.
+type BoltWatchlists struct {
+	db *bolt.DB
+}
+
+// OpenBoltWatchlists opens the database at path, creating it if needed.
+func OpenBoltWatchlists(path string) (*BoltWatchlists, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open watchlists database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(watchlistsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create watchlists bucket: %w", err)
+	}
+
+	return &BoltWatchlists{db: db}, nil
+}
+
+func (s *BoltWatchlists) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltWatchlists) GetWatchlist(ctx context.Context, userID string) (domain.Watchlist, error) {
+	var w domain.Watchlist
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		w, err = getWatchlist(tx, userID)
+		return err
+	})
+	return w, err
+}
+
+func (s *BoltWatchlists) AddToWatchlist(ctx context.Context, userID string, movieID int, addedAt time.Time) (*domain.WatchlistEntry, error) {
+	var entry *domain.WatchlistEntry
+	err := s.update(userID, func(w domain.Watchlist) (domain.Watchlist, error) {
+		w, entry = addEntry(w, movieID, addedAt)
+		return w, nil
+	})
+	return entry, err
+}
+
+func (s *BoltWatchlists) RemoveFromWatchlist(ctx context.Context, userID string, movieID int) error {
+	return s.update(userID, func(w domain.Watchlist) (domain.Watchlist, error) {
+		return removeEntry(w, movieID)
+	})
+}
+
+func (s *BoltWatchlists) SetWatched(ctx context.Context, userID string, movieID int, watchedAt *time.Time) (*domain.WatchlistEntry, error) {
+	var entry *domain.WatchlistEntry
+	err := s.update(userID, func(w domain.Watchlist) (domain.Watchlist, error) {
+		var err error
+		w, entry, err = setWatched(w, movieID, watchedAt)
+		return w, err
+	})
+	return entry, err
+}
+
+// update applies fn to the user's watchlist and stores the result in a single
+// transaction.
+func (s *BoltWatchlists) update(userID string, fn func(domain.Watchlist) (domain.Watchlist, error)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		w, err := getWatchlist(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		w, err = fn(w)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(w)
+		if err != nil {
+			return fmt.Errorf("failed to encode watchlist: %w", err)
+		}
+		return tx.Bucket(watchlistsBucket).Put([]byte(userID), data)
+	})
+}
+
+func getWatchlist(tx *bolt.Tx, userID string) (domain.Watchlist, error) {
+	data := tx.Bucket(watchlistsBucket).Get([]byte(userID))
+	if data == nil {
+		return domain.Watchlist{}, nil
+	}
+
+	var w domain.Watchlist
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, fmt.Errorf("failed to decode watchlist: %w", err)
+	}
+	return w, nil
+}
+
+var (
+	reviewsBucket    = []byte("reviews")
+	tombstonesBucket = []byte("review_tombstones")
+	ratingsBucket    = []byte("ratings")
+)
+
+// BoltReviews is a ReviewStore backed by an embedded bbolt database file.
+// Reviews are stored as JSON keyed by movie ID and user ID, so that a movie's
+// reviews can be read with a prefix scan. Each movie's rating totals are kept
+// alongside them, updated in the same transaction as its reviews, as are the
+// tombstones of deleted reviews that were moderated.
+type BoltReviews struct {
+	db *bolt.DB
+}
+
+// OpenBoltReviews opens the database at path, creating it if needed.
+func OpenBoltReviews(path string) (*BoltReviews, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open reviews database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(reviewsBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(tombstonesBucket); err != nil {
+			return err
+		}
+		if tx.Bucket(ratingsBucket) != nil {
+			return nil
+		}
+		if _, err := tx.CreateBucket(ratingsBucket); err != nil {
+			return err
+		}
+
+		// Databases written before rating totals were kept have them computed
+		// from their reviews once.
+		return tx.Bucket(reviewsBucket).ForEach(func(k, v []byte) error {
+			review, err := decodeReview(v)
+			if err != nil {
+				return err
+			}
+			return updateRatingTotals(tx, review.MovieID, nil, review)
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create reviews buckets: %w", err)
+	}
+
+	return &BoltReviews{db: db}, nil
+}
+
+func (s *BoltReviews) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltReviews) GetRatings(ctx context.Context) (map[int]*domain.Rating, error) {
+	ratings := make(map[int]*domain.Rating)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(ratingsBucket).ForEach(func(k, v []byte) error {
+			movieID, err := strconv.Atoi(string(k))
+			if err != nil {
+				return fmt.Errorf("failed to decode rating totals key: %w", err)
+			}
+
+			totals, err := decodeRatingTotals(v)
+			if err != nil {
+				return err
+			}
+			ratings[movieID] = domain.NewRating(totals.Sum, totals.Count)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ratings, nil
+}
+
+func (s *BoltReviews) GetReviews(ctx context.Context, movieID int) ([]*domain.Review, error) {
+	reviews := []*domain.Review{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prefix := []byte(fmt.Sprintf("%d/", movieID))
+		c := tx.Bucket(reviewsBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			review, err := decodeReview(v)
+			if err != nil {
+				return err
+			}
+			reviews = append(reviews, review)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortReviews(reviews)
+	return reviews, nil
+}
+
+func (s *BoltReviews) GetReview(ctx context.Context, movieID int, userID string) (*domain.Review, error) {
+	var review *domain.Review
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		review, err = getReview(tx, movieID, userID)
+		return err
+	})
+	return review, err
+}
+
+func (s *BoltReviews) PutReview(ctx context.Context, review *domain.Review) (*domain.Review, bool, error) {
+	var merged *domain.Review
+	var created bool
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		existing, err := getReview(tx, review.MovieID, review.UserID)
+		if err != nil && !errors.Is(err, domain.ErrReviewNotFound) {
+			return err
+		}
+
+		var tombstone *domain.Review
+		if existing == nil {
+			key := boltReviewKey(review.MovieID, review.UserID)
+			if data := tx.Bucket(tombstonesBucket).Get(key); data != nil {
+				if tombstone, err = decodeReview(data); err != nil {
+					return err
+				}
+				if err := tx.Bucket(tombstonesBucket).Delete(key); err != nil {
+					return err
+				}
+			}
+		}
+
+		created = existing == nil
+		merged = mergeReview(existing, tombstone, review)
+		return putReview(tx, existing, merged)
+	})
+	return merged, created, err
+}
+
+func (s *BoltReviews) DeleteReview(ctx context.Context, movieID int, userID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		existing, err := getReview(tx, movieID, userID)
+		if err != nil {
+			return err
+		}
+		key := boltReviewKey(movieID, userID)
+		if err := tx.Bucket(reviewsBucket).Delete(key); err != nil {
+			return err
+		}
+		if tombstone := tombstoneReview(existing); tombstone != nil {
+			data, err := json.Marshal(tombstone)
+			if err != nil {
+				return fmt.Errorf("failed to encode review tombstone: %w", err)
+			}
+			if err := tx.Bucket(tombstonesBucket).Put(key, data); err != nil {
+				return err
+			}
+		}
+		return updateRatingTotals(tx, movieID, existing, nil)
+	})
+}
+
+func (s *BoltReviews) FlagReview(ctx context.Context, movieID int, userID string) (*domain.Review, error) {
+	return s.update(movieID, userID, flagReview)
+}
+
+func (s *BoltReviews) SetModeration(ctx context.Context, movieID int, userID string, status domain.ModerationStatus) (*domain.Review, error) {
+	return s.update(movieID, userID, func(review *domain.Review) *domain.Review {
+		return moderateReview(review, status)
+	})
+}
+
+func (s *BoltReviews) update(movieID int, userID string, fn func(*domain.Review) *domain.Review) (*domain.Review, error) {
+	var updated *domain.Review
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		review, err := getReview(tx, movieID, userID)
+		if err != nil {
+			return err
+		}
+
+		updated = fn(review)
+		return putReview(tx, review, updated)
+	})
+	return updated, err
+}
+
+func boltReviewKey(movieID int, userID string) []byte {
+	return []byte(fmt.Sprintf("%d/%s", movieID, userID))
+}
+
+func getReview(tx *bolt.Tx, movieID int, userID string) (*domain.Review, error) {
+	data := tx.Bucket(reviewsBucket).Get(boltReviewKey(movieID, userID))
+	if data == nil {
+		return nil, domain.ErrReviewNotFound
+	}
+	return decodeReview(data)
+}
+
+// putReview stores review in place of the existing one, if any.
+func putReview(tx *bolt.Tx, existing, review *domain.Review) error {
+	data, err := json.Marshal(review)
+	if err != nil {
+		return fmt.Errorf("failed to encode review: %w", err)
+	}
+	if err := tx.Bucket(reviewsBucket).Put(boltReviewKey(review.MovieID, review.UserID), data); err != nil {
+		return err
+	}
+	return updateRatingTotals(tx, review.MovieID, existing, review)
+}
+
+// updateRatingTotals updates the movie's rating totals after review before is
+// replaced by after, either of which may be nil.
+func updateRatingTotals(tx *bolt.Tx, movieID int, before, after *domain.Review) error {
+	b := tx.Bucket(ratingsBucket)
+	key := []byte(strconv.Itoa(movieID))
+
+	var totals ratingTotals
+	if data := b.Get(key); data != nil {
+		var err error
+		if totals, err = decodeRatingTotals(data); err != nil {
+			return err
+		}
+	}
+
+	totals = totals.replace(before, after)
+	if totals.Count == 0 {
+		return b.Delete(key)
+	}
+
+	data, err := json.Marshal(totals)
+	if err != nil {
+		return fmt.Errorf("failed to encode rating totals: %w", err)
+	}
+	return b.Put(key, data)
+}
+
+func decodeRatingTotals(data []byte) (ratingTotals, error) {
+	var totals ratingTotals
+	if err := json.Unmarshal(data, &totals); err != nil {
+		return ratingTotals{}, fmt.Errorf("failed to decode rating totals: %w", err)
+	}
+	return totals, nil
+}
+
+func decodeReview(data []byte) (*domain.Review, error) {
+	var review *domain.Review
+	if err := json.Unmarshal(data, &review); err != nil {
+		return nil, fmt.Errorf("failed to decode review: %w", err)
+	}
+	return review, nil
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+// MemoryWatchlists is a WatchlistStore that keeps watchlists in memory. They
+// are lost when the process exits.
+type MemoryWatchlists struct {
+	mu         sync.RWMutex
+	watchlists map[string]domain.Watchlist
+}
+
+func NewMemoryWatchlists() *MemoryWatchlists {
+	return &MemoryWatchlists{
+		watchlists: make(map[string]domain.Watchlist),
+	}
+}
+
+func (s *MemoryWatchlists) GetWatchlist(ctx context.Context, userID string) (domain.Watchlist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return cloneWatchlist(s.watchlists[userID]), nil
+}
+
+func (s *MemoryWatchlists) AddToWatchlist(ctx context.Context, userID string, movieID int, addedAt time.Time) (*domain.WatchlistEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, entry := addEntry(s.watchlists[userID], movieID, addedAt)
+	s.watchlists[userID] = w
+	return entry, nil
+}
+
+func (s *MemoryWatchlists) RemoveFromWatchlist(ctx context.Context, userID string, movieID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, err := removeEntry(s.watchlists[userID], movieID)
+	if err != nil {
+		return err
+	}
+	s.watchlists[userID] = w
+	return nil
+}
+
+func (s *MemoryWatchlists) SetWatched(ctx context.Context, userID string, movieID int, watchedAt *time.Time) (*domain.WatchlistEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, entry, err := setWatched(s.watchlists[userID], movieID, watchedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.watchlists[userID] = w
+	return entry, nil
+}
+
+type reviewKey struct {
+	movieID int
+	userID  string
+}
+
+// MemoryReviews is a ReviewStore that keeps reviews in memory. They are lost
+// when the process exits.
+type MemoryReviews struct {
+	mu         sync.RWMutex
+	reviews    map[reviewKey]*domain.Review
+	tombstones map[reviewKey]*domain.Review
+	totals     map[int]ratingTotals
+}
+
+func NewMemoryReviews() *MemoryReviews {
+	return &MemoryReviews{
+		reviews:    make(map[reviewKey]*domain.Review),
+		tombstones: make(map[reviewKey]*domain.Review),
+		totals:     make(map[int]ratingTotals),
+	}
+}
+
+func (s *MemoryReviews) GetRatings(ctx context.Context) (map[int]*domain.Rating, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ratings := make(map[int]*domain.Rating, len(s.totals))
+	for movieID, totals := range s.totals {
+		ratings[movieID] = domain.NewRating(totals.Sum, totals.Count)
+	}
+	return ratings, nil
+}
+
+func (s *MemoryReviews) GetReviews(ctx context.Context, movieID int) ([]*domain.Review, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reviews := []*domain.Review{}
+	for key, review := range s.reviews {
+		if key.movieID == movieID {
+			reviews = append(reviews, review)
+		}
+	}
+	sortReviews(reviews)
+	return reviews, nil
+}
+
+func (s *MemoryReviews) GetReview(ctx context.Context, movieID int, userID string) (*domain.Review, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	review, ok := s.reviews[reviewKey{movieID, userID}]
+	if !ok {
+		return nil, domain.ErrReviewNotFound
+	}
+	return review, nil
+}
+
+func (s *MemoryReviews) PutReview(ctx context.Context, review *domain.Review) (*domain.Review, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := reviewKey{review.MovieID, review.UserID}
+	existing := s.reviews[key]
+	s.set(key, existing, mergeReview(existing, s.tombstones[key], review))
+	delete(s.tombstones, key)
+	return s.reviews[key], existing == nil, nil
+}
+
+func (s *MemoryReviews) DeleteReview(ctx context.Context, movieID int, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := reviewKey{movieID, userID}
+	existing, ok := s.reviews[key]
+	if !ok {
+		return domain.ErrReviewNotFound
+	}
+	s.set(key, existing, nil)
+	if tombstone := tombstoneReview(existing); tombstone != nil {
+		s.tombstones[key] = tombstone
+	}
+	return nil
+}
+
+func (s *MemoryReviews) FlagReview(ctx context.Context, movieID int, userID string) (*domain.Review, error) {
+	return s.update(movieID, userID, flagReview)
+}
+
+func (s *MemoryReviews) SetModeration(ctx context.Context, movieID int, userID string, status domain.ModerationStatus) (*domain.Review, error) {
+	return s.update(movieID, userID, func(review *domain.Review) *domain.Review {
+		return moderateReview(review, status)
+	})
+}
+
+func (s *MemoryReviews) update(movieID int, userID string, fn func(*domain.Review) *domain.Review) (*domain.Review, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := reviewKey{movieID, userID}
+	review, ok := s.reviews[key]
+	if !ok {
+		return nil, domain.ErrReviewNotFound
+	}
+	s.set(key, review, fn(review))
+	return s.reviews[key], nil
+}
+
+// set replaces the existing review under key, deleting it if review is nil,
+// and updates the movie's rating totals to match. s.mu must be held.
+func (s *MemoryReviews) set(key reviewKey, existing, review *domain.Review) {
+	if review == nil {
+		delete(s.reviews, key)
+	} else {
+		s.reviews[key] = review
+	}
+
+	totals := s.totals[key.movieID].replace(existing, review)
+	if totals.Count == 0 {
+		delete(s.totals, key.movieID)
+	} else {
+		s.totals[key.movieID] = totals
+	}
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"sort"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+// mergeReview applies an updated review on top of the existing one, if any,
+// keeping its creation time and moderation state. A new review takes its
+// moderation state from the tombstone of a deleted one, if any, so that
+// deleting and re-creating a review doesn't undo its moderation.
+func mergeReview(existing, tombstone, review *domain.Review) *domain.Review {
+	merged := *review
+	merged.UpdatedAt = review.UpdatedAt.UTC()
+
+	if existing == nil {
+		merged.CreatedAt = merged.UpdatedAt
+		merged.Moderation = domain.ModerationPublished
+		merged.Flags = 0
+		if tombstone != nil {
+			merged.Moderation = tombstone.Moderation
+			merged.Flags = tombstone.Flags
+		}
+		return &merged
+	}
+
+	merged.CreatedAt = existing.CreatedAt
+	merged.Moderation = existing.Moderation
+	merged.Flags = existing.Flags
+	return &merged
+}
+
+// tombstoneReview returns what is kept of a deleted review, or nil if it was
+// never moderated and nothing needs keeping.
+func tombstoneReview(review *domain.Review) *domain.Review {
+	if review.Moderation == domain.ModerationPublished && review.Flags == 0 {
+		return nil
+	}
+	return &domain.Review{
+		MovieID:    review.MovieID,
+		UserID:     review.UserID,
+		Moderation: review.Moderation,
+		Flags:      review.Flags,
+	}
+}
+
+func flagReview(review *domain.Review) *domain.Review {
+	flagged := *review
+	flagged.Flags++
+	if flagged.Moderation == domain.ModerationPublished {
+		flagged.Moderation = domain.ModerationFlagged
+	}
+	return &flagged
+}
+
+func moderateReview(review *domain.Review, status domain.ModerationStatus) *domain.Review {
+	moderated := *review
+	moderated.Moderation = status
+	return &moderated
+}
+
+// ratingTotals adds up the ratings of a movie's visible reviews. Stores keep
+// them up to date as reviews change, so ratings are read without going through
+// every review.
+type ratingTotals struct {
+	Sum   int `json:"sum"`
+	Count int `json:"count"`
+}
+
+// replace returns the totals after review before is replaced by after. Either
+// may be nil when a review is created or deleted.
+func (t ratingTotals) replace(before, after *domain.Review) ratingTotals {
+	if before != nil && before.Visible() {
+		t.Sum -= before.Rating
+		t.Count--
+	}
+	if after != nil && after.Visible() {
+		t.Sum += after.Rating
+		t.Count++
+	}
+	return t
+}
+
+func sortReviews(reviews []*domain.Review) {
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].CreatedAt.Equal(reviews[j].CreatedAt) {
+			return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+		}
+		return reviews[i].UserID < reviews[j].UserID
+	})
+}
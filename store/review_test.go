@@ -0,0 +1,174 @@
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/store"
+)
+
+func TestReviewStores(t *testing.T) {
+	tt := []struct {
+		Name  string
+		Setup func(t *testing.T) domain.ReviewStore
+	}{
+		{
+			Name: "Memory",
+			Setup: func(t *testing.T) domain.ReviewStore {
+				return store.NewMemoryReviews()
+			},
+		},
+		{
+			Name: "Bolt",
+			Setup: func(t *testing.T) domain.ReviewStore {
+				s, err := store.OpenBoltReviews(filepath.Join(t.TempDir(), "reviews.db"))
+				assert.NoError(t, err)
+				t.Cleanup(func() { s.Close() })
+				return s
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			s := tc.Setup(t)
+
+			day1 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			day2 := day1.Add(24 * time.Hour)
+
+			review, created, err := s.PutReview(ctx, &domain.Review{MovieID: 1, UserID: "alice", Rating: 8, Text: "Great", UpdatedAt: day1})
+			assert.NoError(t, err)
+			assert.True(t, created)
+			assert.Equal(t, &domain.Review{
+				MovieID:    1,
+				UserID:     "alice",
+				Rating:     8,
+				Text:       "Great",
+				Moderation: domain.ModerationPublished,
+				CreatedAt:  day1,
+				UpdatedAt:  day1,
+			}, review)
+
+			_, _, err = s.PutReview(ctx, &domain.Review{MovieID: 1, UserID: "bob", Rating: 5, UpdatedAt: day2})
+			assert.NoError(t, err)
+			_, _, err = s.PutReview(ctx, &domain.Review{MovieID: 12, UserID: "alice", Rating: 3, UpdatedAt: day1})
+			assert.NoError(t, err)
+
+			review, err = s.FlagReview(ctx, 1, "alice")
+			assert.NoError(t, err)
+			assert.Equal(t, domain.ModerationFlagged, review.Moderation)
+			assert.Equal(t, 1, review.Flags)
+
+			review, created, err = s.PutReview(ctx, &domain.Review{MovieID: 1, UserID: "alice", Rating: 10, Text: "Even better", UpdatedAt: day2})
+			assert.NoError(t, err)
+			assert.False(t, created, "one review per user per movie")
+			assert.Equal(t, day1, review.CreatedAt)
+			assert.Equal(t, day2, review.UpdatedAt)
+			assert.Equal(t, domain.ModerationFlagged, review.Moderation, "editing keeps moderation state")
+
+			reviews, err := s.GetReviews(ctx, 1)
+			assert.NoError(t, err)
+			assert.Len(t, reviews, 2)
+			assert.Equal(t, "bob", reviews[0].UserID, "newest first")
+			assert.Equal(t, "alice", reviews[1].UserID)
+
+			ratings, err := s.GetRatings(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, map[int]*domain.Rating{
+				1:  {Average: 7.5, Count: 2},
+				12: {Average: 3, Count: 1},
+			}, ratings)
+
+			_, err = s.SetModeration(ctx, 1, "alice", domain.ModerationHidden)
+			assert.NoError(t, err)
+
+			ratings, err = s.GetRatings(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, &domain.Rating{Average: 5, Count: 1}, ratings[1], "hidden reviews aren't rated")
+
+			review, err = s.FlagReview(ctx, 1, "alice")
+			assert.NoError(t, err)
+			assert.Equal(t, domain.ModerationHidden, review.Moderation, "flagging keeps hidden reviews hidden")
+
+			assert.NoError(t, s.DeleteReview(ctx, 1, "bob"))
+			assert.ErrorIs(t, s.DeleteReview(ctx, 1, "bob"), domain.ErrReviewNotFound)
+
+			ratings, err = s.GetRatings(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, map[int]*domain.Rating{12: {Average: 3, Count: 1}}, ratings, "movies without visible reviews aren't rated")
+
+			_, err = s.SetModeration(ctx, 1, "alice", domain.ModerationPublished)
+			assert.NoError(t, err)
+
+			ratings, err = s.GetRatings(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, &domain.Rating{Average: 10, Count: 1}, ratings[1], "republished reviews are rated again")
+
+			_, err = s.GetReview(ctx, 1, "bob")
+			assert.ErrorIs(t, err, domain.ErrReviewNotFound)
+			_, err = s.FlagReview(ctx, 1, "bob")
+			assert.ErrorIs(t, err, domain.ErrReviewNotFound)
+
+			_, err = s.SetModeration(ctx, 12, "alice", domain.ModerationHidden)
+			assert.NoError(t, err)
+			assert.NoError(t, s.DeleteReview(ctx, 12, "alice"))
+
+			review, created, err = s.PutReview(ctx, &domain.Review{MovieID: 12, UserID: "alice", Rating: 9, UpdatedAt: day2})
+			assert.NoError(t, err)
+			assert.True(t, created)
+			assert.Equal(t, day2, review.CreatedAt)
+			assert.Equal(t, domain.ModerationHidden, review.Moderation, "deleting and re-creating keeps moderation state")
+
+			ratings, err = s.GetRatings(ctx)
+			assert.NoError(t, err)
+			assert.NotContains(t, ratings, 12)
+
+			reviews, err = s.GetReviews(ctx, 2)
+			assert.NoError(t, err)
+			assert.Empty(t, reviews)
+		})
+	}
+}
+
+func TestBoltReviewsRatingTotals(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "reviews.db")
+
+	s, err := store.OpenBoltReviews(path)
+	assert.NoError(t, err)
+	for _, review := range []*domain.Review{
+		{MovieID: 1, UserID: "alice", Rating: 8},
+		{MovieID: 1, UserID: "bob", Rating: 5},
+		{MovieID: 12, UserID: "alice", Rating: 3},
+	} {
+		_, _, err := s.PutReview(ctx, review)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, s.Close())
+
+	// Drop the rating totals, like a database written before they were kept.
+	db, err := bolt.Open(path, 0o600, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket([]byte("ratings"))
+	}))
+	assert.NoError(t, db.Close())
+
+	s, err = store.OpenBoltReviews(path)
+	assert.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	ratings, err := s.GetRatings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]*domain.Rating{
+		1:  {Average: 6.5, Count: 2},
+		12: {Average: 3, Count: 1},
+	}, ratings)
+}
@@ -0,0 +1,58 @@
+// Package store provides persistent storage for user data.
+package store
+
+import (
+	"time"
+
+	"github.com/jace-ys/simple-api/domain"
+)
+
+// The helpers below apply watchlist changes to a copy of w, so that every
+// WatchlistStore implementation shares the same semantics.
+
+func addEntry(w domain.Watchlist, movieID int, addedAt time.Time) (domain.Watchlist, *domain.WatchlistEntry) {
+	for _, entry := range w {
+		if entry.MovieID == movieID {
+			return w, entry
+		}
+	}
+
+	entry := &domain.WatchlistEntry{MovieID: movieID, AddedAt: addedAt.UTC()}
+	return append(cloneWatchlist(w), entry), entry
+}
+
+func removeEntry(w domain.Watchlist, movieID int) (domain.Watchlist, error) {
+	for i, entry := range w {
+		if entry.MovieID == movieID {
+			removed := cloneWatchlist(w[:i])
+			return append(removed, w[i+1:]...), nil
+		}
+	}
+	return nil, domain.ErrWatchlistEntryNotFound
+}
+
+func setWatched(w domain.Watchlist, movieID int, watchedAt *time.Time) (domain.Watchlist, *domain.WatchlistEntry, error) {
+	for i, entry := range w {
+		if entry.MovieID != movieID {
+			continue
+		}
+
+		updated := *entry
+		updated.WatchedAt = nil
+		if watchedAt != nil {
+			t := watchedAt.UTC()
+			updated.WatchedAt = &t
+		}
+
+		w = cloneWatchlist(w)
+		w[i] = &updated
+		return w, &updated, nil
+	}
+	return nil, nil, domain.ErrWatchlistEntryNotFound
+}
+
+func cloneWatchlist(w domain.Watchlist) domain.Watchlist {
+	clone := make(domain.Watchlist, len(w))
+	copy(clone, w)
+	return clone
+}
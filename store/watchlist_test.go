@@ -0,0 +1,110 @@
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jace-ys/simple-api/domain"
+	"github.com/jace-ys/simple-api/store"
+)
+
+func TestWatchlistStores(t *testing.T) {
+	tt := []struct {
+		Name  string
+		Setup func(t *testing.T) domain.WatchlistStore
+	}{
+		{
+			Name: "Memory",
+			Setup: func(t *testing.T) domain.WatchlistStore {
+				return store.NewMemoryWatchlists()
+			},
+		},
+		{
+			Name: "Bolt",
+			Setup: func(t *testing.T) domain.WatchlistStore {
+				s, err := store.OpenBoltWatchlists(filepath.Join(t.TempDir(), "watchlists.db"))
+				assert.NoError(t, err)
+				t.Cleanup(func() { s.Close() })
+				return s
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			s := tc.Setup(t)
+
+			addedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			watchedAt := addedAt.Add(24 * time.Hour)
+
+			w, err := s.GetWatchlist(ctx, "alice")
+			assert.NoError(t, err)
+			assert.Empty(t, w)
+
+			entry, err := s.AddToWatchlist(ctx, "alice", 1, addedAt)
+			assert.NoError(t, err)
+			assert.Equal(t, &domain.WatchlistEntry{MovieID: 1, AddedAt: addedAt}, entry)
+
+			entry, err = s.AddToWatchlist(ctx, "alice", 1, addedAt.Add(time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, addedAt, entry.AddedAt, "adding twice keeps the original entry")
+
+			_, err = s.AddToWatchlist(ctx, "alice", 2, addedAt)
+			assert.NoError(t, err)
+
+			entry, err = s.SetWatched(ctx, "alice", 2, &watchedAt)
+			assert.NoError(t, err)
+			assert.Equal(t, &watchedAt, entry.WatchedAt)
+
+			_, err = s.SetWatched(ctx, "alice", 3, &watchedAt)
+			assert.ErrorIs(t, err, domain.ErrWatchlistEntryNotFound)
+
+			w, err = s.GetWatchlist(ctx, "alice")
+			assert.NoError(t, err)
+			assert.Equal(t, domain.Watchlist{
+				{MovieID: 1, AddedAt: addedAt},
+				{MovieID: 2, AddedAt: addedAt, WatchedAt: &watchedAt},
+			}, w)
+
+			w, err = s.GetWatchlist(ctx, "bob")
+			assert.NoError(t, err)
+			assert.Empty(t, w, "watchlists are kept per user")
+
+			entry, err = s.SetWatched(ctx, "alice", 2, nil)
+			assert.NoError(t, err)
+			assert.Nil(t, entry.WatchedAt)
+
+			assert.NoError(t, s.RemoveFromWatchlist(ctx, "alice", 1))
+			assert.ErrorIs(t, s.RemoveFromWatchlist(ctx, "alice", 1), domain.ErrWatchlistEntryNotFound)
+
+			w, err = s.GetWatchlist(ctx, "alice")
+			assert.NoError(t, err)
+			assert.Equal(t, domain.Watchlist{{MovieID: 2, AddedAt: addedAt}}, w)
+		})
+	}
+}
+
+func TestBoltWatchlistsPersist(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "watchlists.db")
+	addedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s, err := store.OpenBoltWatchlists(path)
+	assert.NoError(t, err)
+	_, err = s.AddToWatchlist(ctx, "alice", 1, addedAt)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Close())
+
+	s, err = store.OpenBoltWatchlists(path)
+	assert.NoError(t, err)
+	defer s.Close()
+
+	w, err := s.GetWatchlist(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Watchlist{{MovieID: 1, AddedAt: addedAt}}, w)
+}